@@ -18,7 +18,7 @@ func init() {
 func main() {
 	scraperToRun := os.Getenv("SCRAPER")
 	if scraperToRun == "" {
-		log.Fatal("SCRAPER environment variable is required (options: coursebook, grades, rmp-profiles, integration)")
+		log.Fatal("SCRAPER environment variable is required (options: coursebook, grades, rmp-profiles, integration, ingest, refresh)")
 	}
 
 	log.Println("Running scraper:", scraperToRun)
@@ -43,8 +43,22 @@ func main() {
 			log.Fatalf("failed to configure integration handler: %v", handlerErr)
 		}
 		runErr = integrationHandler.IntegrationStart()
+	case "ingest":
+		// Load existing scraper output into the configured storage backend
+		ingestHandler, handlerErr := scraper.NewIngestHandler(service)
+		if handlerErr != nil {
+			log.Fatalf("failed to configure ingest handler: %v", handlerErr)
+		}
+		runErr = ingestHandler.IngestStart()
+	case "refresh":
+		// Rewrite stored sections so they pick up newly added derived fields
+		ingestHandler, handlerErr := scraper.NewIngestHandler(service)
+		if handlerErr != nil {
+			log.Fatalf("failed to configure ingest handler: %v", handlerErr)
+		}
+		runErr = ingestHandler.RefreshStart()
 	default:
-		log.Fatalf("Invalid SCRAPER value: %s (options: coursebook, grades, rmp-profiles, integration)", scraperToRun)
+		log.Fatalf("Invalid SCRAPER value: %s (options: coursebook, grades, rmp-profiles, integration, ingest, refresh)", scraperToRun)
 	}
 
 	if runErr != nil {
@@ -11,6 +11,7 @@ import (
 
 	"cloud.google.com/go/firestore"
 	firebase "firebase.google.com/go/v4"
+	"github.com/acmutd/acmutd-api/internal/storage"
 	"github.com/acmutd/acmutd-api/internal/types"
 	"google.golang.org/api/iterator"
 	"google.golang.org/grpc/codes"
@@ -21,6 +22,14 @@ type Firestore struct {
 	*firestore.Client
 }
 
+var (
+	_ storage.Store  = (*Firestore)(nil)
+	_ storage.Writer = (*Firestore)(nil)
+)
+
+// firestoreInLimit is the most values an "in" filter accepts.
+const firestoreInLimit = 30
+
 func NewFirestore(ctx context.Context, app *firebase.App) (*Firestore, error) {
 
 	client, err := app.Firestore(ctx)
@@ -45,67 +54,6 @@ func normalizeTerm(value string) string {
 	return strings.ToLower(strings.TrimSpace(value))
 }
 
-func sanitizeDocID(value string) string {
-	sanitized := strings.TrimSpace(value)
-	sanitized = strings.ReplaceAll(sanitized, "/", "-")
-	sanitized = strings.ReplaceAll(sanitized, " ", "")
-	return sanitized
-}
-
-func ensureSectionDocID(course types.Course, term string) string {
-	if section := sanitizeDocID(course.SectionAddress); section != "" {
-		return strings.ToLower(section)
-	}
-
-	prefix := sanitizeDocID(normalizeCoursePrefix(course.CoursePrefix))
-	number := sanitizeDocID(normalizeCourseNumber(course.CourseNumber))
-	section := sanitizeDocID(strings.ToLower(course.Section))
-	if section == "" {
-		section = "000"
-	}
-	normalizedTerm := sanitizeDocID(normalizeTerm(term))
-
-	generated := fmt.Sprintf("%s%s.%s.%s", prefix, number, section, normalizedTerm)
-	generated = strings.ReplaceAll(generated, "..", ".")
-	generated = strings.Trim(generated, ".")
-
-	return generated
-}
-
-type preparedCourse struct {
-	Course    types.Course
-	PrefixID  string
-	NumberID  string
-	SectionID string
-}
-
-func prepareCourseForTerm(course types.Course, normalizedTerm string) (preparedCourse, bool) {
-	if normalizedTerm == "" {
-		return preparedCourse{}, false
-	}
-
-	course.Term = normalizedTerm
-	course.CoursePrefix = normalizeCoursePrefix(course.CoursePrefix)
-	course.CourseNumber = normalizeCourseNumber(course.CourseNumber)
-	course.Section = strings.ToLower(strings.TrimSpace(course.Section))
-
-	prefixID := sanitizeDocID(course.CoursePrefix)
-	numberID := sanitizeDocID(course.CourseNumber)
-	if prefixID == "" || numberID == "" {
-		return preparedCourse{}, false
-	}
-
-	sectionID := ensureSectionDocID(course, normalizedTerm)
-	course.SectionAddress = sectionID
-
-	return preparedCourse{
-		Course:    course,
-		PrefixID:  prefixID,
-		NumberID:  numberID,
-		SectionID: sectionID,
-	}, true
-}
-
 func (c *Firestore) sectionsCollection(prefixID, numberID string) *firestore.CollectionRef {
 	return c.Collection("courses").
 		Doc(prefixID).
@@ -114,6 +62,14 @@ func (c *Firestore) sectionsCollection(prefixID, numberID string) *firestore.Col
 		Collection("sections")
 }
 
+func (c *Firestore) gradeRecordsCollection(prefixID, numberID string) *firestore.CollectionRef {
+	return c.Collection("grades").
+		Doc(prefixID).
+		Collection("courses").
+		Doc(numberID).
+		Collection("records")
+}
+
 /*
 Structure:
 
@@ -125,31 +81,39 @@ Structure:
     queries by term, course prefix, and course number while maintaining fast prefix
     lookups for each term.
 */
-func (c *Firestore) InsertClassesWithIndexes(ctx context.Context, courses []types.Course, term string) {
-	writer := c.BulkWriter(ctx)
-	defer writer.End()
-
+func (c *Firestore) InsertClassesWithIndexes(ctx context.Context, courses []types.Course, term string) (int, error) {
 	normalizedTerm := normalizeTerm(term)
 	if normalizedTerm == "" {
-		return
+		return 0, fmt.Errorf("term is required")
 	}
 
+	writer := c.BulkWriter(ctx)
+
 	termDoc := c.Collection("terms").Doc(normalizedTerm)
-	writer.Set(termDoc, map[string]any{
+	if _, err := writer.Set(termDoc, map[string]any{
 		"term":         normalizedTerm,
 		"last_updated": time.Now(),
-	}, firestore.MergeAll)
+	}, firestore.MergeAll); err != nil {
+		writer.End()
+		return 0, fmt.Errorf("failed to queue term %s: %w", normalizedTerm, err)
+	}
 
 	prefixes := make(map[string]string)
+	var jobs []*firestore.BulkWriterJob
 
 	for _, course := range courses {
-		prepared, ok := prepareCourseForTerm(course, normalizedTerm)
+		prepared, ok := storage.PrepareCourse(course, normalizedTerm)
 		if !ok {
 			continue
 		}
 
 		doc := c.sectionsCollection(prepared.PrefixID, prepared.NumberID).Doc(prepared.SectionID)
-		writer.Set(doc, prepared.Course)
+		job, err := writer.Set(doc, prepared.Course)
+		if err != nil {
+			writer.End()
+			return 0, fmt.Errorf("failed to queue section %s: %w", prepared.SectionID, err)
+		}
+		jobs = append(jobs, job)
 
 		if _, exists := prefixes[prepared.PrefixID]; !exists {
 			prefixes[prepared.PrefixID] = prepared.Course.CoursePrefix
@@ -167,6 +131,31 @@ func (c *Firestore) InsertClassesWithIndexes(ctx context.Context, courses []type
 			firestore.MergeAll,
 		)
 	}
+
+	writer.End()
+
+	return collectWriteResults(jobs, "sections")
+}
+
+// collectWriteResults waits on queued BulkWriter jobs and counts successful writes.
+func collectWriteResults(jobs []*firestore.BulkWriterJob, kind string) (int, error) {
+	written := 0
+	var firstErr error
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		written++
+	}
+
+	if firstErr != nil {
+		return written, fmt.Errorf("failed to write %d of %d %s: %w", len(jobs)-written, len(jobs), kind, firstErr)
+	}
+
+	return written, nil
 }
 
 func (c *Firestore) InsertTerms(ctx context.Context, terms []string) {
@@ -186,16 +175,11 @@ func (c *Firestore) InsertTerms(ctx context.Context, terms []string) {
 	}
 }
 
-func (c *Firestore) QueryAllTerms(ctx context.Context, limit, offset int) ([]string, bool, error) {
-	query := c.Collection("terms").OrderBy("term", firestore.Asc)
-	if offset > 0 {
-		query = query.Offset(offset)
-	}
-	if limit > 0 {
-		query = query.Limit(limit + 1)
-	}
-
-	iter := query.Documents(ctx)
+// QueryAllTerms pages through the terms collection in chronological order.
+// The collection holds one small document per term, so it is read in full and
+// ordered in memory.
+func (c *Firestore) QueryAllTerms(ctx context.Context, page storage.Page) ([]string, string, error) {
+	iter := c.Collection("terms").Documents(ctx)
 	defer iter.Stop()
 
 	var terms []string
@@ -205,7 +189,7 @@ func (c *Firestore) QueryAllTerms(ctx context.Context, limit, offset int) ([]str
 			break
 		}
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to get next term: %w", err)
+			return nil, "", fmt.Errorf("failed to get next term: %w", err)
 		}
 
 		data := doc.Data()
@@ -217,22 +201,15 @@ func (c *Firestore) QueryAllTerms(ctx context.Context, limit, offset int) ([]str
 		terms = append(terms, doc.Ref.ID)
 	}
 
-	sort.Strings(terms)
-
-	hasNext := false
-	if limit > 0 && len(terms) > limit {
-		hasNext = true
-		terms = terms[:limit]
-	}
-
-	return terms, hasNext, nil
+	return storage.PageTerms(terms, page)
 }
-func (c *Firestore) QueryByCourseNumber(ctx context.Context, term, coursePrefix, courseNumber string, limit, offset int) ([]types.Course, bool, error) {
+
+func (c *Firestore) QueryByCourseNumber(ctx context.Context, term, coursePrefix, courseNumber string, page storage.Page) ([]types.Course, string, error) {
 	term = normalizeTerm(term)
 	coursePrefix = normalizeCoursePrefix(coursePrefix)
 	courseNumber = normalizeCourseNumber(courseNumber)
 	if term == "" || coursePrefix == "" || courseNumber == "" {
-		return []types.Course{}, false, nil
+		return []types.Course{}, "", nil
 	}
 
 	query := c.CollectionGroup("sections").
@@ -240,147 +217,217 @@ func (c *Firestore) QueryByCourseNumber(ctx context.Context, term, coursePrefix,
 		Where("course_prefix", "==", coursePrefix).
 		Where("course_number", "==", courseNumber)
 
-	return c.collectCourses(ctx, query, limit, offset)
+	return c.collectCourses(ctx, query, page)
 }
 
-func (c *Firestore) QueryByCoursePrefix(ctx context.Context, term, coursePrefix string, limit, offset int) ([]types.Course, bool, error) {
+func (c *Firestore) QueryByCoursePrefix(ctx context.Context, term, coursePrefix string, page storage.Page) ([]types.Course, string, error) {
 	term = normalizeTerm(term)
 	coursePrefix = normalizeCoursePrefix(coursePrefix)
 	if term == "" || coursePrefix == "" {
-		return []types.Course{}, false, nil
+		return []types.Course{}, "", nil
 	}
 
 	query := c.CollectionGroup("sections").
 		Where("term", "==", term).
 		Where("course_prefix", "==", coursePrefix)
 
-	return c.collectCourses(ctx, query, limit, offset)
+	return c.collectCourses(ctx, query, page)
 }
 
 // GetAllCoursesByTerm returns all courses for a given term
-func (c *Firestore) GetAllCoursesByTerm(ctx context.Context, term string, limit, offset int) ([]types.Course, bool, error) {
+func (c *Firestore) GetAllCoursesByTerm(ctx context.Context, term string, page storage.Page) ([]types.Course, string, error) {
 	term = normalizeTerm(term)
 	if term == "" {
-		return []types.Course{}, false, nil
+		return []types.Course{}, "", nil
 	}
 
 	query := c.CollectionGroup("sections").
 		Where("term", "==", term)
 
-	return c.collectCourses(ctx, query, limit, offset)
+	return c.collectCourses(ctx, query, page)
 }
 
 // QueryBySchool returns courses by school for a given term
-func (c *Firestore) QueryBySchool(ctx context.Context, term, school string, limit, offset int) ([]types.Course, bool, error) {
+func (c *Firestore) QueryBySchool(ctx context.Context, term, school string, page storage.Page) ([]types.Course, string, error) {
 	term = normalizeTerm(term)
 	school = strings.TrimSpace(school)
 	if term == "" || school == "" {
-		return []types.Course{}, false, nil
+		return []types.Course{}, "", nil
 	}
 
 	query := c.CollectionGroup("sections").
 		Where("term", "==", term).
 		Where("school", "==", school)
 
-	return c.collectCourses(ctx, query, limit, offset)
+	return c.collectCourses(ctx, query, page)
 }
 
-func (c *Firestore) collectCourses(ctx context.Context, query firestore.Query, limit, offset int) ([]types.Course, bool, error) {
-	if limit > 0 {
-		query = query.Offset(offset).Limit(limit + 1)
+// GetCoursesByAddress fetches sections with a single GetAll call, building each
+// document reference from the course code in its address. Addresses without a
+// course code are looked up by their section_address field instead.
+func (c *Firestore) GetCoursesByAddress(ctx context.Context, addresses []string) ([]types.Course, error) {
+	var refs []*firestore.DocumentRef
+	var unparsed []any
+	var order []string
+	for _, address := range addresses {
+		prefixID, numberID, sectionID, ok := storage.SectionDocIDs(address)
+		if sectionID == "" {
+			continue
+		}
+		order = append(order, sectionID)
+		if ok {
+			refs = append(refs, c.sectionsCollection(prefixID, numberID).Doc(sectionID))
+		} else {
+			unparsed = append(unparsed, sectionID)
+		}
 	}
 
-	iter := query.Documents(ctx)
-	defer iter.Stop()
-
-	var courses []types.Course
-	for {
-		doc, err := iter.Next()
-		if err == iterator.Done {
-			break
+	var docs []*firestore.DocumentSnapshot
+	if len(refs) > 0 {
+		found, err := c.GetAll(ctx, refs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sections: %w", err)
 		}
+		docs = append(docs, found...)
+	}
+	for start := 0; start < len(unparsed); start += firestoreInLimit {
+		chunk := unparsed[start:min(start+firestoreInLimit, len(unparsed))]
+		found, err := c.CollectionGroup("sections").
+			Where("section_address", "in", chunk).
+			Documents(ctx).GetAll()
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to get next document: %w", err)
+			return nil, fmt.Errorf("failed to get sections by address: %w", err)
 		}
+		docs = append(docs, found...)
+	}
 
+	byAddress := make(map[string]types.Course, len(docs))
+	for _, doc := range docs {
+		if !doc.Exists() {
+			continue
+		}
 		var course types.Course
 		if err := doc.DataTo(&course); err != nil {
 			continue
 		}
-		courses = append(courses, course)
+		byAddress[course.SectionAddress] = course
 	}
 
-	hasNext := false
-	if limit > 0 && len(courses) > limit {
-		hasNext = true
-		courses = courses[:limit]
+	courses := make([]types.Course, 0, len(byAddress))
+	for _, sectionID := range order {
+		if course, ok := byAddress[sectionID]; ok {
+			courses = append(courses, course)
+		}
 	}
-
-	return courses, hasNext, nil
+	return courses, nil
 }
 
-// SearchCourses searches courses by title, topic, or instructor name
-func (c *Firestore) SearchCourses(ctx context.Context, term, searchQuery string, limit, offset int) ([]types.Course, bool, error) {
-	// TODO: Figure out a nicer way to do this
-	normalizedTerm := normalizeTerm(term)
-	courses, _, err := c.GetAllCoursesByTerm(ctx, normalizedTerm, 0, 0)
-	if err != nil {
-		return nil, false, err
+// QueryByInstructor lists a term's sections taught by an instructor using an
+// array-contains query on instructor_id_list, which ingestion fills in from
+// instructor_ids.
+func (c *Firestore) QueryByInstructor(ctx context.Context, term, instructorID string, page storage.Page) ([]types.Course, string, error) {
+	term = normalizeTerm(term)
+	instructorID = storage.NormalizeInstructorID(instructorID)
+	if term == "" || instructorID == "" {
+		return []types.Course{}, "", nil
 	}
 
-	query := strings.ToLower(strings.TrimSpace(searchQuery))
-	if query == "" {
-		if limit <= 0 {
-			return courses, false, nil
-		}
-		start := offset
-		if start >= len(courses) {
-			return []types.Course{}, false, nil
-		}
-		end := offset + limit
-		if end > len(courses) {
-			end = len(courses)
-		}
-		hasNext := end < len(courses)
-		return courses[start:end], hasNext, nil
+	query := c.CollectionGroup("sections").
+		Where("term", "==", term).
+		Where("instructor_id_list", "array-contains", instructorID)
+
+	return c.collectCourses(ctx, query, page)
+}
+
+// GetCourseByClassNumber finds a term's section by class number.
+func (c *Firestore) GetCourseByClassNumber(ctx context.Context, term, classNumber string) (*types.Course, error) {
+	term = normalizeTerm(term)
+	classNumber = strings.TrimSpace(classNumber)
+	if term == "" || classNumber == "" {
+		return nil, storage.ErrNotFound
 	}
 
-	var filteredCourses []types.Course
+	iter := c.CollectionGroup("sections").
+		Where("term", "==", term).
+		Where("class_number", "==", classNumber).
+		Limit(1).
+		Documents(ctx)
+	defer iter.Stop()
 
-	for _, course := range courses {
-		title := strings.ToLower(course.Title)
-		topic := strings.ToLower(course.Topic)
-		instructors := strings.ToLower(course.Instructors)
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get section by class number: %w", err)
+	}
 
-		// Search in title, topic, and instructors
-		if strings.Contains(title, query) ||
-			strings.Contains(topic, query) ||
-			strings.Contains(instructors, query) {
-			filteredCourses = append(filteredCourses, course)
-		}
+	var course types.Course
+	if err := doc.DataTo(&course); err != nil {
+		return nil, fmt.Errorf("failed to decode section: %w", err)
 	}
+	return &course, nil
+}
 
-	if limit <= 0 {
-		return filteredCourses, false, nil
+// collectCourses runs a sections query in document path order, resuming after
+// the page cursor when one is given.
+func (c *Firestore) collectCourses(ctx context.Context, query firestore.Query, page storage.Page) ([]types.Course, string, error) {
+	query = query.OrderBy(firestore.DocumentID, firestore.Asc)
+	if page.Cursor != "" {
+		keys, err := storage.DecodeCursor(page.Cursor, storage.CourseCursor, 3)
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.StartAfter(c.sectionsCollection(keys[0], keys[1]).Doc(keys[2]))
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
 	}
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit + 1)
+	}
+
+	iter := query.Documents(ctx)
+	defer iter.Stop()
+
+	var courses []types.Course
+	var refs []*firestore.DocumentRef
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get next document: %w", err)
+		}
 
-	start := offset
-	if start >= len(filteredCourses) {
-		return []types.Course{}, false, nil
+		var course types.Course
+		if err := doc.DataTo(&course); err != nil {
+			continue
+		}
+		courses = append(courses, course)
+		refs = append(refs, doc.Ref)
 	}
 
-	end := offset + limit
-	if end > len(filteredCourses) {
-		end = len(filteredCourses)
+	nextCursor := ""
+	if page.Limit > 0 && len(courses) > page.Limit {
+		courses = courses[:page.Limit]
+		nextCursor = nestedDocCursor(storage.CourseCursor, refs[page.Limit-1])
 	}
 
-	hasNext := end < len(filteredCourses)
+	return courses, nextCursor, nil
+}
 
-	return filteredCourses[start:end], hasNext, nil
+// nestedDocCursor encodes a {collection}/{a}/{sub}/{b}/{leaf}/{id} document as
+// the three IDs needed to rebuild its reference for StartAfter.
+func nestedDocCursor(kind string, ref *firestore.DocumentRef) string {
+	numberDoc := ref.Parent.Parent
+	prefixDoc := numberDoc.Parent.Parent
+	return storage.EncodeCursor(kind, prefixDoc.ID, numberDoc.ID, ref.ID)
 }
 
-// GetSchoolsByTerm returns all schools for a given term
-func (c *Firestore) GetSchoolsByTerm(ctx context.Context, term string) ([]string, error) {
+// GetPrefixesByTerm returns the course prefixes offered in a term, read from
+// terms/{term}/prefixes with a scan of the term's sections as a fallback.
+func (c *Firestore) GetPrefixesByTerm(ctx context.Context, term string) ([]string, error) {
 	term = normalizeTerm(term)
 	if term == "" {
 		return nil, nil
@@ -463,9 +510,29 @@ func (c *Firestore) GetSchoolsByTerm(ctx context.Context, term string) ([]string
 	return prefixes, nil
 }
 
+// professorDocID returns the professors document ID for an instructor ID.
+func professorDocID(id string) string {
+	return storage.SanitizeDocID(storage.NormalizeInstructorID(id))
+}
+
+// GetProfessorById loads a professor by instructor ID, falling back to the ID
+// as given for documents written before IDs were normalized.
 func (c *Firestore) GetProfessorById(ctx context.Context, id string) (*types.Professor, error) {
-	doc, err := c.Collection("professors").Doc(id).Get(ctx)
+	docID := professorDocID(id)
+	if docID == "" {
+		return nil, storage.ErrNotFound
+	}
+
+	doc, err := c.Collection("professors").Doc(docID).Get(ctx)
+	if status.Code(err) == codes.NotFound {
+		if legacyID := storage.SanitizeDocID(id); legacyID != docID {
+			doc, err = c.Collection("professors").Doc(legacyID).Get(ctx)
+		}
+	}
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storage.ErrNotFound
+		}
 		return nil, err
 	}
 
@@ -477,30 +544,148 @@ func (c *Firestore) GetProfessorById(ctx context.Context, id string) (*types.Pro
 	return &professor, nil
 }
 
-func (c *Firestore) GetProfessorsByName(ctx context.Context, name string, limit, offset int) ([]types.Professor, bool, error) {
+func (c *Firestore) ListProfessors(ctx context.Context) ([]types.Professor, error) {
+	iter := c.Collection("professors").Documents(ctx)
+	defer iter.Stop()
+
+	var professors []types.Professor
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get next professor: %w", err)
+		}
+
+		var professor types.Professor
+		if err := doc.DataTo(&professor); err != nil {
+			continue
+		}
+		if professor.InstructorID == "" {
+			professor.InstructorID = doc.Ref.ID
+		}
+		professors = append(professors, professor)
+	}
+
+	return professors, nil
+}
+
+// UpsertProfessors writes each professor to professors/{instructor_id}.
+func (c *Firestore) UpsertProfessors(ctx context.Context, professors []types.Professor) (int, error) {
+	writer := c.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	for _, professor := range professors {
+		id := professorDocID(professor.InstructorID)
+		if id == "" {
+			continue
+		}
+
+		job, err := writer.Set(c.Collection("professors").Doc(id), professor)
+		if err != nil {
+			writer.End()
+			return 0, fmt.Errorf("failed to queue professor %s: %w", id, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	writer.End()
+
+	return collectWriteResults(jobs, "professors")
+}
+
+func (c *Firestore) DeleteProfessors(ctx context.Context, ids []string) (int, error) {
+	writer := c.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	for _, id := range ids {
+		id = professorDocID(id)
+		if id == "" {
+			continue
+		}
+
+		job, err := writer.Delete(c.Collection("professors").Doc(id))
+		if err != nil {
+			writer.End()
+			return 0, fmt.Errorf("failed to queue professor deletion %s: %w", id, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	writer.End()
+
+	return collectWriteResults(jobs, "professor deletions")
+}
+
+func (c *Firestore) GetProfessorsByRMPID(ctx context.Context, rmpID string) ([]types.Professor, error) {
+	rmpID = strings.TrimSpace(rmpID)
+	if rmpID == "" {
+		return []types.Professor{}, nil
+	}
+
+	iter := c.Collection("professors").
+		Where("rmp_id", "==", rmpID).
+		OrderBy(firestore.DocumentID, firestore.Asc).
+		Documents(ctx)
+	defer iter.Stop()
+
+	professors := []types.Professor{}
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get professors by RMP ID: %w", err)
+		}
+
+		var professor types.Professor
+		if err := doc.DataTo(&professor); err != nil {
+			return nil, fmt.Errorf("failed to decode professor: %w", err)
+		}
+		if professor.InstructorID == "" {
+			professor.InstructorID = doc.Ref.ID
+		}
+		professors = append(professors, professor)
+	}
+
+	return professors, nil
+}
+
+func (c *Firestore) GetProfessorsByName(ctx context.Context, name string, page storage.Page) ([]types.Professor, string, error) {
 	normalizedName := strings.ToLower(strings.TrimSpace(name))
 	if normalizedName == "" {
-		return []types.Professor{}, false, nil
+		return []types.Professor{}, "", nil
 	}
 
-	query := c.Collection("professors").Where("normalized_coursebook_name", "==", normalizedName)
-	if offset > 0 {
-		query = query.Offset(offset)
+	query := c.Collection("professors").
+		Where("normalized_coursebook_name", "==", normalizedName).
+		OrderBy(firestore.DocumentID, firestore.Asc)
+	if page.Cursor != "" {
+		keys, err := storage.DecodeCursor(page.Cursor, storage.ProfessorCursor, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.StartAfter(c.Collection("professors").Doc(keys[0]))
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
 	}
-	if limit > 0 {
-		query = query.Limit(limit + 1)
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit + 1)
 	}
 	iter := query.Documents(ctx)
 	defer iter.Stop()
 
 	var professors []types.Professor
+	var ids []string
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to get next professor: %w", err)
+			return nil, "", fmt.Errorf("failed to get next professor: %w", err)
 		}
 
 		var professor types.Professor
@@ -508,57 +693,110 @@ func (c *Firestore) GetProfessorsByName(ctx context.Context, name string, limit,
 			continue
 		}
 		professors = append(professors, professor)
+		ids = append(ids, doc.Ref.ID)
+	}
+
+	nextCursor := ""
+	if page.Limit > 0 && len(professors) > page.Limit {
+		professors = professors[:page.Limit]
+		nextCursor = storage.EncodeCursor(storage.ProfessorCursor, ids[page.Limit-1])
 	}
-	hasNext := false
-	if limit > 0 && len(professors) > limit {
-		hasNext = true
-		professors = professors[:limit]
+
+	return professors, nextCursor, nil
+}
+
+/*
+Structure:
+
+  - grades/{course_prefix}/courses/{course_number}/records/{record_id}
+
+    record_id is {prefix}{number}.{section}.{term}, so loading the same grade
+    files again overwrites the existing records instead of duplicating them.
+*/
+func (c *Firestore) UpsertGrades(ctx context.Context, grades []types.Grades) (int, error) {
+	writer := c.BulkWriter(ctx)
+
+	var jobs []*firestore.BulkWriterJob
+	for _, grade := range grades {
+		prepared, ok := storage.PrepareGrade(grade)
+		if !ok {
+			continue
+		}
+
+		doc := c.gradeRecordsCollection(prepared.PrefixID, prepared.NumberID).Doc(prepared.RecordID)
+		job, err := writer.Set(doc, prepared.Grade)
+		if err != nil {
+			writer.End()
+			return 0, fmt.Errorf("failed to queue grade record %s: %w", prepared.RecordID, err)
+		}
+		jobs = append(jobs, job)
 	}
 
-	return professors, hasNext, nil
+	writer.End()
+
+	return collectWriteResults(jobs, "grade records")
 }
 
-func (c *Firestore) GetGradesByPrefix(ctx context.Context, prefix string, limit, offset int) ([]types.Grades, bool, error) {
-	query := c.CollectionGroup("records").Where("course_prefix", "==", prefix)
-	return c.collectGrades(ctx, query, limit, offset)
+func (c *Firestore) GetGradesByPrefix(ctx context.Context, prefix string, page storage.Page) ([]types.Grades, string, error) {
+	query := c.CollectionGroup("records").Where("course_prefix", "==", normalizeCoursePrefix(prefix))
+	return c.collectGrades(ctx, query, page)
 }
 
-func (c *Firestore) GetGradesByPrefixAndNumber(ctx context.Context, prefix, number string, limit, offset int) ([]types.Grades, bool, error) {
-	records := c.Collection("grades").Doc(prefix).Collection("courses").Doc(number).Collection("records")
-	return c.collectGrades(ctx, records.Query, limit, offset)
+func (c *Firestore) GetGradesByPrefixAndNumber(ctx context.Context, prefix, number string, page storage.Page) ([]types.Grades, string, error) {
+	records := c.gradeRecordsCollection(normalizeCoursePrefix(prefix), normalizeCourseNumber(number))
+	return c.collectGrades(ctx, records.Query, page)
 }
 
-func (c *Firestore) GetGradesByPrefixAndTerm(ctx context.Context, prefix, term string, limit, offset int) ([]types.Grades, bool, error) {
-	query := c.CollectionGroup("records").Where("course_prefix", "==", prefix).Where("term", "==", term)
-	return c.collectGrades(ctx, query, limit, offset)
+func (c *Firestore) GetGradesByPrefixAndTerm(ctx context.Context, prefix, term string, page storage.Page) ([]types.Grades, string, error) {
+	query := c.CollectionGroup("records").Where("course_prefix", "==", normalizeCoursePrefix(prefix)).Where("term", "==", normalizeTerm(term))
+	return c.collectGrades(ctx, query, page)
 }
 
-func (c *Firestore) GetGradesByProfId(ctx context.Context, profId string, limit, offset int) ([]types.Grades, bool, error) {
-	query := c.CollectionGroup("records").Where("instructor_id", "==", profId)
-	return c.collectGrades(ctx, query, limit, offset)
+// GetGradesByProfId lists an instructor's grade records. Records written before
+// instructor IDs were normalized are matched by the ID as given.
+func (c *Firestore) GetGradesByProfId(ctx context.Context, profId string, page storage.Page) ([]types.Grades, string, error) {
+	ids := []string{storage.NormalizeInstructorID(profId)}
+	if trimmed := strings.TrimSpace(profId); trimmed != ids[0] {
+		ids = append(ids, trimmed)
+	}
+	query := c.CollectionGroup("records").Where("instructor_id", "in", ids)
+	return c.collectGrades(ctx, query, page)
 }
 
-func (c *Firestore) GetGradesByProfName(ctx context.Context, profName string, limit, offset int) ([]types.Grades, bool, error) {
+func (c *Firestore) GetGradesByProfName(ctx context.Context, profName string, page storage.Page) ([]types.Grades, string, error) {
 	query := c.CollectionGroup("records").Where("instructor_name_normalized", "==", profName)
-	return c.collectGrades(ctx, query, limit, offset)
+	return c.collectGrades(ctx, query, page)
 }
 
-func (c *Firestore) collectGrades(ctx context.Context, query firestore.Query, limit, offset int) ([]types.Grades, bool, error) {
-	if limit > 0 {
-		query = query.Offset(offset).Limit(limit + 1)
+// collectGrades runs a records query in document path order, resuming after
+// the page cursor when one is given.
+func (c *Firestore) collectGrades(ctx context.Context, query firestore.Query, page storage.Page) ([]types.Grades, string, error) {
+	query = query.OrderBy(firestore.DocumentID, firestore.Asc)
+	if page.Cursor != "" {
+		keys, err := storage.DecodeCursor(page.Cursor, storage.GradeCursor, 3)
+		if err != nil {
+			return nil, "", err
+		}
+		query = query.StartAfter(c.gradeRecordsCollection(keys[0], keys[1]).Doc(keys[2]))
+	} else if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit + 1)
 	}
 
 	iter := query.Documents(ctx)
 	defer iter.Stop()
 
 	var grades []types.Grades
+	var refs []*firestore.DocumentRef
 	for {
 		doc, err := iter.Next()
 		if err == iterator.Done {
 			break
 		}
 		if err != nil {
-			return nil, false, fmt.Errorf("failed to get next grade: %w", err)
+			return nil, "", fmt.Errorf("failed to get next grade: %w", err)
 		}
 
 		var grade types.Grades
@@ -566,15 +804,16 @@ func (c *Firestore) collectGrades(ctx context.Context, query firestore.Query, li
 			continue
 		}
 		grades = append(grades, grade)
+		refs = append(refs, doc.Ref)
 	}
 
-	hasNext := false
-	if limit > 0 && len(grades) > limit {
-		hasNext = true
-		grades = grades[:limit]
+	nextCursor := ""
+	if page.Limit > 0 && len(grades) > page.Limit {
+		grades = grades[:page.Limit]
+		nextCursor = nestedDocCursor(storage.GradeCursor, refs[page.Limit-1])
 	}
 
-	return grades, hasNext, nil
+	return grades, nextCursor, nil
 }
 
 func (c *Firestore) GenerateAPIKey(
@@ -634,6 +873,9 @@ func (c *Firestore) UpdateKeyUsage(ctx context.Context, key string) error {
 func (c *Firestore) GetAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
 	doc, err := c.Collection("api_keys").Doc(key).Get(ctx)
 	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, storage.ErrNotFound
+		}
 		return nil, err
 	}
 
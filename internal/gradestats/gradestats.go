@@ -0,0 +1,163 @@
+// Package gradestats rolls grade distributions up across sections and terms so
+// a course or instructor can be summarized as a whole.
+package gradestats
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// Summary is the combined distribution of a set of grade records.
+type Summary struct {
+	CoursePrefix   string            `json:"course_prefix,omitempty"`
+	CourseNumber   string            `json:"course_number,omitempty"`
+	InstructorID   string            `json:"instructor_id,omitempty"`
+	InstructorName string            `json:"instructor_name,omitempty"`
+	Terms          []string          `json:"terms"`    // Terms covered, oldest first
+	Sections       int               `json:"sections"` // Grade records combined
+	Counts         types.GradeCounts `json:"counts"`
+	Stats          types.GradeStats  `json:"stats"`
+}
+
+// Summarize combines every record into one summary. Course and instructor
+// fields are set only when all records share them.
+func Summarize(records []types.Grades) Summary {
+	summary := Summary{Terms: []string{}, Counts: types.GradeCounts{}}
+	for i, record := range records {
+		if i == 0 {
+			summary.CoursePrefix = record.CoursePrefix
+			summary.CourseNumber = record.CourseNumber
+			summary.InstructorID = types.NormalizeInstructorID(record.InstructorID)
+			summary.InstructorName = instructorName(record)
+		} else {
+			if record.CoursePrefix != summary.CoursePrefix || record.CourseNumber != summary.CourseNumber {
+				summary.CoursePrefix, summary.CourseNumber = "", ""
+			}
+			if instructorKey(record) != instructorKey(records[0]) {
+				summary.InstructorID, summary.InstructorName = "", ""
+			}
+		}
+
+		if !slices.Contains(summary.Terms, record.Term) {
+			summary.Terms = append(summary.Terms, record.Term)
+		}
+		summary.Sections++
+		summary.Counts.Add(record.Counts())
+	}
+	types.SortTermCodes(summary.Terms)
+	summary.Stats = summary.Counts.Stats()
+	return summary
+}
+
+// ByCourse summarizes the records of each course, ordered by prefix and number.
+func ByCourse(records []types.Grades) []Summary {
+	summaries := group(records, func(record types.Grades) string {
+		return record.CoursePrefix + " " + record.CourseNumber
+	})
+	slices.SortFunc(summaries, func(a, b Summary) int {
+		if c := strings.Compare(a.CoursePrefix, b.CoursePrefix); c != 0 {
+			return c
+		}
+		return strings.Compare(a.CourseNumber, b.CourseNumber)
+	})
+	return summaries
+}
+
+// ByInstructor summarizes the records of each instructor, ordered by the
+// number of students graded, most first.
+func ByInstructor(records []types.Grades) []Summary {
+	summaries := group(records, instructorKey)
+	slices.SortFunc(summaries, func(a, b Summary) int {
+		if c := cmp.Compare(b.Stats.TotalStudents, a.Stats.TotalStudents); c != 0 {
+			return c
+		}
+		return strings.Compare(a.InstructorName, b.InstructorName)
+	})
+	return summaries
+}
+
+// TrendPoint is the combined distribution of one term.
+type TrendPoint struct {
+	Term     string             `json:"term"`
+	Sections int                `json:"sections"` // Grade records combined
+	Stats    types.GradeStats   `json:"stats"`
+	Shares   map[string]float64 `json:"shares"` // Fraction of students in each bucket
+}
+
+// Trend combines the records of each term, oldest term first.
+func Trend(records []types.Grades) []TrendPoint {
+	byTerm := make(map[string][]types.Grades)
+	var terms []string
+	for _, record := range records {
+		if _, ok := byTerm[record.Term]; !ok {
+			terms = append(terms, record.Term)
+		}
+		byTerm[record.Term] = append(byTerm[record.Term], record)
+	}
+	types.SortTermCodes(terms)
+
+	points := make([]TrendPoint, 0, len(terms))
+	for _, term := range terms {
+		summary := Summarize(byTerm[term])
+		points = append(points, TrendPoint{
+			Term:     term,
+			Sections: summary.Sections,
+			Stats:    summary.Stats,
+			Shares:   summary.Counts.Shares(),
+		})
+	}
+	return points
+}
+
+// group summarizes the records sharing each key, in order of first appearance.
+func group(records []types.Grades, key func(types.Grades) string) []Summary {
+	var keys []string
+	groups := make(map[string][]types.Grades)
+	for _, record := range records {
+		k := key(record)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], record)
+	}
+
+	summaries := make([]Summary, 0, len(keys))
+	for _, k := range keys {
+		summaries = append(summaries, Summarize(groups[k]))
+	}
+	return summaries
+}
+
+// instructorKey identifies a record's instructor by normalized ID, falling
+// back to the normalized name for records without one.
+func instructorKey(record types.Grades) string {
+	if id := types.NormalizeInstructorID(record.InstructorID); id != "" {
+		return id
+	}
+	return "name:" + record.InstructorNameNormalized
+}
+
+func instructorName(record types.Grades) string {
+	if name := strings.TrimSpace(record.Instructor1); name != "" {
+		return name
+	}
+	return record.InstructorNameNormalized
+}
+
+// Record is a grade distribution along with its computed statistics.
+type Record struct {
+	types.Grades
+	Stats types.GradeStats `json:"stats"`
+}
+
+// Records computes the statistics of each grade record.
+func Records(grades []types.Grades) []Record {
+	records := make([]Record, len(grades))
+	for i, grade := range grades {
+		records[i] = Record{Grades: grade, Stats: grade.Stats()}
+	}
+	return records
+}
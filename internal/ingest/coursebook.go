@@ -0,0 +1,190 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// CoursebookFile is the parsed contents of one classes_{term}.json file.
+type CoursebookFile struct {
+	Name     string
+	Courses  map[string][]types.Course // keyed by normalized term
+	Parsed   map[string]int
+	Rejected []Rejection
+	// rejectedTerms records the term (when known) of each rejection for reporting.
+	rejectedTerms []string
+}
+
+// termFromCoursebookFile extracts the term from names like classes_24f.json.
+func termFromCoursebookFile(name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	if !strings.HasPrefix(base, "classes_") {
+		return ""
+	}
+	return storage.NormalizeTerm(strings.TrimPrefix(base, "classes_"))
+}
+
+// cleanCourse trims the stray whitespace coursebook leaves on many fields.
+func cleanCourse(course types.Course) types.Course {
+	fields := []*string{
+		&course.SectionAddress, &course.CoursePrefix, &course.CourseNumber, &course.Section, &course.Term,
+		&course.ClassNumber, &course.Title, &course.Topic,
+		&course.EnrolledStatus, &course.EnrolledCurrent, &course.EnrolledMax,
+		&course.Instructors, &course.InstructorIDs, &course.Assistants,
+		&course.Session, &course.Days, &course.Times, &course.Times12h, &course.Location,
+		&course.CoreArea, &course.ActivityType, &course.Dept,
+		&course.Syllabus, &course.Textbooks,
+	}
+	for _, field := range fields {
+		*field = strings.TrimSpace(*field)
+	}
+	course.School = types.School(strings.TrimSpace(string(course.School)))
+
+	return course
+}
+
+// ParseCoursebookFile decodes a coursebook scraper output file, grouping valid
+// sections by term and recording every record that cannot be stored, including
+// sections that repeat the document ID of an earlier one.
+func ParseCoursebookFile(path string) (*CoursebookFile, error) {
+	return parseCoursebookFile(path, recordIDs{})
+}
+
+// parseCoursebookFile is ParseCoursebookFile with the section IDs already read
+// from other files, so duplicates are caught across a whole load.
+func parseCoursebookFile(path string, ids recordIDs) (*CoursebookFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coursebook file: %w", err)
+	}
+
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode coursebook file %s: %w", path, err)
+	}
+
+	name := filepath.Base(path)
+	fileTerm := termFromCoursebookFile(name)
+	parsed := &CoursebookFile{
+		Name:    name,
+		Courses: make(map[string][]types.Course),
+		Parsed:  make(map[string]int),
+	}
+
+	reject := func(index int, term, id, reason string) {
+		parsed.Rejected = append(parsed.Rejected, Rejection{File: name, Index: index, ID: id, Reason: reason})
+		parsed.rejectedTerms = append(parsed.rejectedTerms, term)
+	}
+
+	for i, record := range records {
+		var course types.Course
+		if err := json.Unmarshal(record, &course); err != nil {
+			if fileTerm != "" {
+				parsed.Parsed[fileTerm]++
+			}
+			reject(i, fileTerm, "", fmt.Sprintf("invalid record: %v", err))
+			continue
+		}
+		course = cleanCourse(course)
+
+		term := storage.NormalizeTerm(course.Term)
+		if term == "" {
+			term = fileTerm
+		}
+		if term == "" {
+			reject(i, "", course.SectionAddress, "missing term")
+			continue
+		}
+		parsed.Parsed[term]++
+
+		prepared, ok := storage.PrepareCourse(course, term)
+		if !ok {
+			reason := "missing course number"
+			if storage.NormalizeCoursePrefix(course.CoursePrefix) == "" {
+				reason = "missing course prefix"
+			}
+			reject(i, term, course.SectionAddress, reason)
+			continue
+		}
+		if first := ids.claim(prepared.SectionID, fmt.Sprintf("%s[%d]", name, i)); first != "" {
+			reject(i, term, prepared.SectionID, "duplicate section ID, first read at "+first)
+			continue
+		}
+
+		parsed.Courses[term] = append(parsed.Courses[term], course)
+	}
+
+	return parsed, nil
+}
+
+/*
+IngestCoursebook parses every coursebook JSON file in dir and writes the
+sections into the hierarchical course layout:
+
+  - courses/{course_prefix}/numbers/{course_number}/sections/{section_address}
+  - terms/{term}/prefixes/{course_prefix}
+
+Files that cannot be decoded are reported as a single rejection and skipped.
+A section whose ID was already read in this load is rejected rather than
+overwriting the earlier one.
+*/
+func IngestCoursebook(ctx context.Context, writer storage.CourseWriter, dir string) (*Report, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read coursebook directory: %w", err)
+	}
+
+	report := newReport("Coursebook")
+	byTerm := make(map[string][]types.Course)
+	ids := recordIDs{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		parsed, err := parseCoursebookFile(filepath.Join(dir, entry.Name()), ids)
+		if err != nil {
+			report.reject(termFromCoursebookFile(entry.Name()), Rejection{File: entry.Name(), Index: -1, Reason: err.Error()})
+			continue
+		}
+
+		for term, count := range parsed.Parsed {
+			report.term(term).Parsed += count
+		}
+		for i, rejection := range parsed.Rejected {
+			report.reject(parsed.rejectedTerms[i], rejection)
+		}
+		for term, courses := range parsed.Courses {
+			byTerm[term] = append(byTerm[term], courses...)
+		}
+	}
+
+	if len(byTerm) == 0 {
+		return report, fmt.Errorf("no coursebook sections found in %s", dir)
+	}
+
+	terms := make([]string, 0, len(byTerm))
+	for term := range byTerm {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		written, err := writer.InsertClassesWithIndexes(ctx, byTerm[term], term)
+		report.term(term).Written += written
+		if err != nil {
+			return report, fmt.Errorf("failed to write term %s: %w", term, err)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,36 @@
+package ingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+)
+
+func TestIngestCoursebookRejectsDuplicateSections(t *testing.T) {
+	dir := t.TempDir()
+	sections := `[
+		{"section_address": "cs3345.001.24f", "course_prefix": "cs", "course_number": "3345", "section": "001", "term": "24f"},
+		{"section_address": "cs3345.002.24f", "course_prefix": "cs", "course_number": "3345", "section": "002", "term": "24f"},
+		{"section_address": "CS3345.001.24F ", "course_prefix": "cs", "course_number": "3345", "section": "001", "term": "24f"}
+	]`
+	if err := os.WriteFile(filepath.Join(dir, "classes_24f.json"), []byte(sections), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := IngestCoursebook(context.Background(), memory.New(), dir)
+	if err != nil {
+		t.Fatalf("IngestCoursebook: %v", err)
+	}
+
+	counts := report.Terms["24f"]
+	if counts.Parsed != 3 || counts.Written != 2 || counts.Rejected != 1 {
+		t.Errorf("24f counts = %+v, want 3 parsed, 2 written, 1 rejected", *counts)
+	}
+	want := "classes_24f.json[2] (cs3345.001.24f): duplicate section ID, first read at classes_24f.json[0]"
+	if len(report.Rejected) != 1 || report.Rejected[0].String() != want {
+		t.Errorf("rejected %v, want [%s]", report.Rejected, want)
+	}
+}
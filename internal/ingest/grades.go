@@ -0,0 +1,217 @@
+package ingest
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// GradesFile is the parsed contents of one enhanced_grades_{term}.csv file.
+type GradesFile struct {
+	Name     string
+	Term     string
+	Grades   []types.Grades
+	Parsed   int
+	Rejected []Rejection
+}
+
+// termFromGradesFile extracts the term from names like enhanced_grades_24f.csv
+// or grades_24f.csv.
+func termFromGradesFile(name string) string {
+	base := strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	base = strings.TrimPrefix(base, "enhanced_")
+	if !strings.HasPrefix(base, "grades_") {
+		return ""
+	}
+	return storage.NormalizeTerm(strings.TrimPrefix(base, "grades_"))
+}
+
+// gradeColumns maps the CSV headers written by the grades and integration
+// scripts onto the fields of a grade record.
+func gradeColumns(grade *types.Grades) map[string]*string {
+	return map[string]*string{
+		"Subject":                    &grade.CoursePrefix,
+		"Catalog Nbr":                &grade.CourseNumber,
+		"Section":                    &grade.Section,
+		"Instructor 1":               &grade.Instructor1,
+		"Instructor 2":               &grade.Instructor2,
+		"Instructor 3":               &grade.Instructor3,
+		"Instructor 4":               &grade.Instructor4,
+		"Instructor 5":               &grade.Instructor5,
+		"Instructor 6":               &grade.Instructor6,
+		"instructor_id":              &grade.InstructorID,
+		"instructor_name_normalized": &grade.InstructorNameNormalized,
+		"A+":                         &grade.APlus,
+		"A":                          &grade.A,
+		"A-":                         &grade.AMinus,
+		"B+":                         &grade.BPlus,
+		"B":                          &grade.B,
+		"B-":                         &grade.BMinus,
+		"C+":                         &grade.CPlus,
+		"C":                          &grade.C,
+		"C-":                         &grade.CMinus,
+		"D+":                         &grade.DPlus,
+		"D":                          &grade.D,
+		"D-":                         &grade.DMinus,
+		"F":                          &grade.F,
+		"NF":                         &grade.NF,
+		"CR":                         &grade.CR,
+		"I":                          &grade.I,
+		"NC":                         &grade.NC,
+		"P":                          &grade.P,
+		"W":                          &grade.W,
+	}
+}
+
+// normalizeHeader strips the BOM and stray quotes Excel exports leave on headers.
+func normalizeHeader(header string) string {
+	header = strings.TrimPrefix(header, "\ufeff")
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(header), `"`))
+}
+
+// ParseGradesFile decodes an enhanced grades CSV into grade records for the
+// term named in the file. Rows that repeat the record ID of an earlier row are
+// rejected.
+func ParseGradesFile(path string) (*GradesFile, error) {
+	return parseGradesFile(path, recordIDs{})
+}
+
+// parseGradesFile is ParseGradesFile with the record IDs already read from
+// other files, so duplicates are caught across a whole load.
+func parseGradesFile(path string, ids recordIDs) (*GradesFile, error) {
+	name := filepath.Base(path)
+	term := termFromGradesFile(name)
+	if term == "" {
+		return nil, fmt.Errorf("cannot determine term from file name %s", name)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open grades file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read grades header in %s: %w", name, err)
+	}
+	for i := range header {
+		header[i] = normalizeHeader(header[i])
+	}
+
+	parsed := &GradesFile{Name: name, Term: term}
+
+	for index := 0; ; index++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		parsed.Parsed++
+		if err != nil {
+			parsed.Rejected = append(parsed.Rejected, Rejection{File: name, Index: index, Reason: fmt.Sprintf("invalid row: %v", err)})
+			continue
+		}
+
+		grade := types.Grades{Term: term}
+		columns := gradeColumns(&grade)
+		for i, value := range row {
+			if i >= len(header) {
+				break
+			}
+			if field, ok := columns[header[i]]; ok {
+				*field = strings.TrimSpace(value)
+			}
+		}
+
+		id := strings.TrimSpace(grade.CoursePrefix + grade.CourseNumber + "." + grade.Section)
+		switch {
+		case storage.NormalizeCoursePrefix(grade.CoursePrefix) == "":
+			parsed.Rejected = append(parsed.Rejected, Rejection{File: name, Index: index, ID: id, Reason: "missing subject"})
+			continue
+		case storage.NormalizeCourseNumber(grade.CourseNumber) == "":
+			parsed.Rejected = append(parsed.Rejected, Rejection{File: name, Index: index, ID: id, Reason: "missing catalog number"})
+			continue
+		}
+
+		if prepared, ok := storage.PrepareGrade(grade); ok {
+			if first := ids.claim(prepared.RecordID, fmt.Sprintf("%s[%d]", name, index)); first != "" {
+				parsed.Rejected = append(parsed.Rejected, Rejection{File: name, Index: index, ID: prepared.RecordID, Reason: "duplicate record ID, first read at " + first})
+				continue
+			}
+		}
+
+		parsed.Grades = append(parsed.Grades, grade)
+	}
+
+	return parsed, nil
+}
+
+/*
+IngestGrades parses every grades CSV in dir and upserts the records into:
+
+  - grades/{course_prefix}/courses/{course_number}/records/{record_id}
+
+Record IDs are derived from the prefix, number, section, and term, so running
+the loader again over the same files is idempotent. Within one load, a row
+whose record ID was already read is rejected rather than overwriting it.
+*/
+func IngestGrades(ctx context.Context, writer storage.GradeWriter, dir string) (*Report, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read grades directory: %w", err)
+	}
+
+	report := newReport("Grades")
+	byTerm := make(map[string][]types.Grades)
+	ids := recordIDs{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".csv") {
+			continue
+		}
+
+		parsed, err := parseGradesFile(filepath.Join(dir, entry.Name()), ids)
+		if err != nil {
+			report.reject(termFromGradesFile(entry.Name()), Rejection{File: entry.Name(), Index: -1, Reason: err.Error()})
+			continue
+		}
+
+		report.term(parsed.Term).Parsed += parsed.Parsed
+		for _, rejection := range parsed.Rejected {
+			report.reject(parsed.Term, rejection)
+		}
+		byTerm[parsed.Term] = append(byTerm[parsed.Term], parsed.Grades...)
+	}
+
+	if len(byTerm) == 0 {
+		return report, fmt.Errorf("no grade records found in %s", dir)
+	}
+
+	terms := make([]string, 0, len(byTerm))
+	for term := range byTerm {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		written, err := writer.UpsertGrades(ctx, byTerm[term])
+		report.term(term).Written += written
+		if err != nil {
+			return report, fmt.Errorf("failed to write grades for term %s: %w", term, err)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,45 @@
+package ingest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+)
+
+func TestIngestGradesRejectsDuplicateRecordIDs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"enhanced_grades_24f.csv": "Subject,Catalog Nbr,Section,A\nCS,3345,001,10\nCS,3345,002,12\ncs,3345,001,99\n",
+		"grades_24f.csv":          "Subject,Catalog Nbr,Section,A\nCS,3345,002,7\nCS,3354,001,5\n",
+	}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	report, err := IngestGrades(context.Background(), memory.New(), dir)
+	if err != nil {
+		t.Fatalf("IngestGrades: %v", err)
+	}
+
+	counts := report.Terms["24f"]
+	if counts.Parsed != 5 || counts.Written != 3 || counts.Rejected != 2 {
+		t.Errorf("24f counts = %+v, want 5 parsed, 3 written, 2 rejected", *counts)
+	}
+	want := []string{
+		"enhanced_grades_24f.csv[2] (cs3345.001.24f): duplicate record ID, first read at enhanced_grades_24f.csv[0]",
+		"grades_24f.csv[0] (cs3345.002.24f): duplicate record ID, first read at enhanced_grades_24f.csv[1]",
+	}
+	if len(report.Rejected) != len(want) {
+		t.Fatalf("rejected %v, want %d rejections", report.Rejected, len(want))
+	}
+	for i, rejection := range report.Rejected {
+		if rejection.String() != want[i] {
+			t.Errorf("rejection %d = %q, want %q", i, rejection, want[i])
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// maxLoggedChanges caps how many instructor IDs are printed per diff category.
+const maxLoggedChanges = 25
+
+// professorRecord mirrors one value of matched_professor_data.json. The
+// integration script writes "N/A" for ratings it could not compute, so those
+// fields are decoded leniently.
+type professorRecord struct {
+	types.Professor
+	OverallGradeRating json.RawMessage            `json:"overall_grade_rating"`
+	CourseRatings      map[string]json.RawMessage `json:"course_ratings"`
+}
+
+// parseRating accepts a JSON number or numeric string and rejects "N/A" and null.
+func parseRating(raw json.RawMessage) (float64, bool) {
+	if len(raw) == 0 {
+		return 0, false
+	}
+
+	var value float64
+	if err := json.Unmarshal(raw, &value); err == nil {
+		return value, true
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return 0, false
+	}
+	return value, true
+}
+
+// ProfessorReport summarizes a professor load as a diff against the stored collection.
+type ProfessorReport struct {
+	Parsed    int
+	Written   int
+	Unchanged int
+	Added     []string
+	Changed   []string
+	Removed   []string
+	Pruned    int
+	Rejected  []Rejection
+}
+
+// Log prints the diff counts along with the first instructor IDs in each category.
+func (r *ProfessorReport) Log() {
+	log.Println("Professors ingestion summary:")
+	log.Printf("  %d parsed, %d written, %d rejected", r.Parsed, r.Written, len(r.Rejected))
+	log.Printf("  %d added, %d changed, %d unchanged, %d removed (%d deleted)",
+		len(r.Added), len(r.Changed), r.Unchanged, len(r.Removed), r.Pruned)
+
+	logIDs := func(label string, ids []string) {
+		if len(ids) == 0 {
+			return
+		}
+		shown := ids
+		if len(shown) > maxLoggedChanges {
+			shown = shown[:maxLoggedChanges]
+		}
+		line := strings.Join(shown, ", ")
+		if len(ids) > len(shown) {
+			line += fmt.Sprintf(", ... and %d more", len(ids)-len(shown))
+		}
+		log.Printf("  %s: %s", label, line)
+	}
+	logIDs("Added", r.Added)
+	logIDs("Changed", r.Changed)
+	logIDs("Removed", r.Removed)
+
+	if len(r.Rejected) == 0 {
+		return
+	}
+
+	log.Printf("  Rejected records: %d", len(r.Rejected))
+	for i, rejection := range r.Rejected {
+		if i == maxLoggedRejections {
+			log.Printf("    ... and %d more", len(r.Rejected)-maxLoggedRejections)
+			break
+		}
+		log.Printf("    - %s", rejection)
+	}
+}
+
+// ParseProfessorsFile decodes matched_professor_data.json, which is keyed by
+// instructor_id, into professors sorted by ID.
+func ParseProfessorsFile(path string) ([]types.Professor, []Rejection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read professors file: %w", err)
+	}
+
+	var records map[string]json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode professors file %s: %w", path, err)
+	}
+
+	ids := make([]string, 0, len(records))
+	for id := range records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	name := filepath.Base(path)
+	var professors []types.Professor
+	var rejected []Rejection
+
+	for i, id := range ids {
+		instructorID := storage.NormalizeInstructorID(id)
+		if instructorID == "" || storage.SanitizeDocID(instructorID) != instructorID {
+			rejected = append(rejected, Rejection{File: name, Index: i, ID: id, Reason: "invalid instructor_id"})
+			continue
+		}
+
+		var record professorRecord
+		if err := json.Unmarshal(records[id], &record); err != nil {
+			rejected = append(rejected, Rejection{File: name, Index: i, ID: id, Reason: fmt.Sprintf("invalid record: %v", err)})
+			continue
+		}
+
+		professor := record.Professor
+		professor.InstructorID = instructorID
+		professor.NormalizedCoursebookName = strings.ToLower(strings.TrimSpace(professor.NormalizedCoursebookName))
+		professor.OverallGradeRating, _ = parseRating(record.OverallGradeRating)
+		professor.CourseRatings = make(map[string]float64, len(record.CourseRatings))
+		for course, raw := range record.CourseRatings {
+			if rating, ok := parseRating(raw); ok {
+				professor.CourseRatings[course] = rating
+			}
+		}
+		if professor.Tags == nil {
+			professor.Tags = []string{}
+		}
+
+		professors = append(professors, professor)
+	}
+
+	return professors, rejected, nil
+}
+
+// professorFingerprint encodes a professor so stored and incoming records can
+// be compared regardless of nil versus empty collections.
+func professorFingerprint(professor types.Professor) []byte {
+	if professor.Tags == nil {
+		professor.Tags = []string{}
+	}
+	if professor.CourseRatings == nil {
+		professor.CourseRatings = map[string]float64{}
+	}
+	data, _ := json.Marshal(professor)
+	return data
+}
+
+/*
+IngestProfessors writes matched_professor_data.json into professors/{instructor_id}
+and reports which professors were added, changed, or are no longer present in
+the file. Only added and changed professors are written. Removed professors are
+deleted only when prune is true, so a partial integration run cannot wipe out
+existing profiles.
+*/
+func IngestProfessors(ctx context.Context, writer storage.ProfessorWriter, path string, prune bool) (*ProfessorReport, error) {
+	professors, rejected, err := ParseProfessorsFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ProfessorReport{
+		Parsed:   len(professors) + len(rejected),
+		Rejected: rejected,
+	}
+	if len(professors) == 0 {
+		return report, fmt.Errorf("no professors found in %s", path)
+	}
+
+	existing, err := writer.ListProfessors(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list existing professors: %w", err)
+	}
+	current := make(map[string][]byte, len(existing))
+	for _, professor := range existing {
+		// Professors stored before IDs were normalized are matched case-insensitively.
+		current[storage.NormalizeInstructorID(professor.InstructorID)] = professorFingerprint(professor)
+	}
+
+	incoming := make(map[string]struct{}, len(professors))
+	var pending []types.Professor
+	for _, professor := range professors {
+		incoming[professor.InstructorID] = struct{}{}
+
+		previous, ok := current[professor.InstructorID]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, professor.InstructorID)
+		case !bytes.Equal(previous, professorFingerprint(professor)):
+			report.Changed = append(report.Changed, professor.InstructorID)
+		default:
+			report.Unchanged++
+			continue
+		}
+		pending = append(pending, professor)
+	}
+
+	for id := range current {
+		if _, ok := incoming[id]; !ok {
+			report.Removed = append(report.Removed, id)
+		}
+	}
+	sort.Strings(report.Removed)
+
+	written, err := writer.UpsertProfessors(ctx, pending)
+	report.Written = written
+	if err != nil {
+		return report, fmt.Errorf("failed to write professors: %w", err)
+	}
+
+	if prune && len(report.Removed) > 0 {
+		deleted, err := writer.DeleteProfessors(ctx, report.Removed)
+		report.Pruned = deleted
+		if err != nil {
+			return report, fmt.Errorf("failed to delete removed professors: %w", err)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,35 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+)
+
+// RefreshCourses rewrites every stored section through the current
+// storage.PrepareCourse, filling in fields added to the stored layout after the
+// section was ingested, such as instructor_id_list. It needs no scraper output.
+func RefreshCourses(ctx context.Context, store storage.CourseStore, writer storage.CourseWriter) (*Report, error) {
+	terms, _, err := store.QueryAllTerms(ctx, storage.Page{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list terms: %w", err)
+	}
+
+	report := newReport("Course refresh")
+	for _, term := range terms {
+		courses, _, err := store.GetAllCoursesByTerm(ctx, term, storage.Page{})
+		if err != nil {
+			return report, fmt.Errorf("failed to load term %s: %w", term, err)
+		}
+		report.term(term).Parsed += len(courses)
+
+		written, err := writer.InsertClassesWithIndexes(ctx, courses, term)
+		report.term(term).Written += written
+		if err != nil {
+			return report, fmt.Errorf("failed to write term %s: %w", term, err)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,107 @@
+// Package ingest loads scraper output from disk into a storage backend.
+package ingest
+
+import (
+	"fmt"
+	"log"
+	"sort"
+)
+
+// maxLoggedRejections caps how many rejected records are printed in a summary.
+const maxLoggedRejections = 25
+
+// Report summarizes one ingestion run.
+type Report struct {
+	Source   string
+	Terms    map[string]*TermCounts
+	Rejected []Rejection
+}
+
+// TermCounts tracks records seen for a single term.
+type TermCounts struct {
+	Parsed   int
+	Written  int
+	Rejected int
+}
+
+// Rejection describes a record that could not be ingested.
+type Rejection struct {
+	File   string
+	Index  int
+	ID     string
+	Reason string
+}
+
+func (r Rejection) String() string {
+	location := fmt.Sprintf("%s[%d]", r.File, r.Index)
+	if r.ID != "" {
+		location += " (" + r.ID + ")"
+	}
+	return location + ": " + r.Reason
+}
+
+// recordIDs remembers where each record ID was first read, so a later record
+// with the same ID is rejected instead of silently overwriting it when written.
+type recordIDs map[string]string
+
+// claim registers id as read at location. It returns "" for a new ID, or the
+// location of the record that already holds it.
+func (ids recordIDs) claim(id, location string) string {
+	if first, ok := ids[id]; ok {
+		return first
+	}
+	ids[id] = location
+	return ""
+}
+
+func newReport(source string) *Report {
+	return &Report{
+		Source: source,
+		Terms:  make(map[string]*TermCounts),
+	}
+}
+
+func (r *Report) term(term string) *TermCounts {
+	counts, ok := r.Terms[term]
+	if !ok {
+		counts = &TermCounts{}
+		r.Terms[term] = counts
+	}
+	return counts
+}
+
+func (r *Report) reject(term string, rejection Rejection) {
+	r.Rejected = append(r.Rejected, rejection)
+	if term != "" {
+		r.term(term).Rejected++
+	}
+}
+
+// Log prints per-term counts and the first rejected records.
+func (r *Report) Log() {
+	log.Printf("%s ingestion summary:", r.Source)
+
+	terms := make([]string, 0, len(r.Terms))
+	for term := range r.Terms {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	for _, term := range terms {
+		counts := r.Terms[term]
+		log.Printf("  %s: %d parsed, %d written, %d rejected", term, counts.Parsed, counts.Written, counts.Rejected)
+	}
+
+	if len(r.Rejected) == 0 {
+		return
+	}
+
+	log.Printf("  Rejected records: %d", len(r.Rejected))
+	for i, rejection := range r.Rejected {
+		if i == maxLoggedRejections {
+			log.Printf("    ... and %d more", len(r.Rejected)-maxLoggedRejections)
+			break
+		}
+		log.Printf("    - %s", rejection)
+	}
+}
@@ -0,0 +1,74 @@
+// Package schedule works out how course sections fit together in a student's
+// week: which meetings overlap and which sets of sections can be taken together.
+package schedule
+
+import (
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// Overlap is the window during which two sections meet at the same time on a
+// given day.
+type Overlap struct {
+	Day          string `json:"day"`
+	StartMinutes int    `json:"start_minutes"`
+	EndMinutes   int    `json:"end_minutes"`
+}
+
+// Conflict is a pair of sections with at least one overlapping meeting.
+type Conflict struct {
+	Sections [2]string `json:"sections"` // Section addresses, in request order
+	Overlaps []Overlap `json:"overlaps"`
+}
+
+// Overlaps returns every window in which the two sections meet at once.
+// Meetings that only touch, with one ending the minute the other starts, do
+// not overlap.
+func Overlaps(a, b types.Course) []Overlap {
+	a.EnsureScheduleFields()
+	b.EnsureScheduleFields()
+
+	var overlaps []Overlap
+	for _, ma := range a.Meetings {
+		for _, mb := range b.Meetings {
+			if ma.Day != mb.Day {
+				continue
+			}
+			start := max(ma.StartMinutes, mb.StartMinutes)
+			end := min(ma.EndMinutes, mb.EndMinutes)
+			if start < end {
+				overlaps = append(overlaps, Overlap{Day: ma.Day, StartMinutes: start, EndMinutes: end})
+			}
+		}
+	}
+	return overlaps
+}
+
+// FindConflicts checks every pair of sections and returns the pairs that
+// overlap, ordered by the position of the sections in courses.
+func FindConflicts(courses []types.Course) []Conflict {
+	conflicts := []Conflict{}
+	for i := range courses {
+		for j := i + 1; j < len(courses); j++ {
+			if overlaps := Overlaps(courses[i], courses[j]); len(overlaps) > 0 {
+				conflicts = append(conflicts, Conflict{
+					Sections: [2]string{courses[i].SectionAddress, courses[j].SectionAddress},
+					Overlaps: overlaps,
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// Unscheduled returns the addresses of sections with no parsed meetings, such
+// as online or TBA sections, which can never conflict.
+func Unscheduled(courses []types.Course) []string {
+	addresses := []string{}
+	for _, course := range courses {
+		course.EnsureScheduleFields()
+		if len(course.Meetings) == 0 {
+			addresses = append(addresses, course.SectionAddress)
+		}
+	}
+	return addresses
+}
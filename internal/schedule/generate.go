@@ -0,0 +1,312 @@
+package schedule
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+const (
+	// MaxCourses caps how many courses a single generation request can ask for.
+	MaxCourses = 8
+	// maxCombinations bounds how many conflict-free schedules are considered
+	// before ranking, so requests with many sections per course stay fast.
+	maxCombinations = 5000
+	// maxConflictChecks bounds the pairs of sections the search compares, so
+	// requests whose sections mostly conflict stay fast even when they yield
+	// few or no schedules.
+	maxConflictChecks = 500000
+	// unratedScore ranks sections whose instructors have no ratings in the
+	// middle of the scale rather than at either end.
+	unratedScore = 0.5
+)
+
+// Constraints limit which sections the generator may pick. Zero values place
+// no limit; the time bounds are pointers so midnight can still be a bound.
+type Constraints struct {
+	EarliestStart *int           // Minutes after midnight no meeting may start before
+	LatestEnd     *int           // Minutes after midnight no meeting may end after
+	DaysOff       []time.Weekday // Days with no meetings
+	OpenOnly      bool           // Only sections with seats remaining
+	MinRating     float64        // Minimum instructor quality rating (0-5)
+}
+
+// Rating summarizes the instructors of a section. A section taught by several
+// instructors takes its best-rated instructor.
+type Rating struct {
+	Quality float64 `json:"quality_rating"`       // RateMyProfessors quality rating (0-5)
+	Grade   float64 `json:"overall_grade_rating"` // Average grade given (GPA scale)
+	Rated   bool    `json:"rated"`
+}
+
+// RateSection combines the instructors' quality and grade ratings.
+func RateSection(professors []types.Professor) Rating {
+	var rating Rating
+	for _, professor := range professors {
+		if professor.RatingsCount == 0 && professor.TotalGradeCount == 0 {
+			continue
+		}
+		rating.Rated = true
+		rating.Quality = max(rating.Quality, professor.QualityRating)
+		rating.Grade = max(rating.Grade, professor.OverallGradeRating)
+	}
+	return rating
+}
+
+// score maps a rating onto 0-1, averaging quality (out of 5) and grade (out of 4).
+func (r Rating) score() float64 {
+	switch {
+	case !r.Rated:
+		return unratedScore
+	case r.Quality > 0 && r.Grade > 0:
+		return (r.Quality/5 + r.Grade/4) / 2
+	case r.Quality > 0:
+		return r.Quality / 5
+	case r.Grade > 0:
+		return r.Grade / 4
+	default:
+		return unratedScore
+	}
+}
+
+// Allows reports whether a section with the given rating satisfies the
+// constraints. Instructors without ratings never meet a minimum rating.
+func (c Constraints) Allows(course types.Course, rating Rating) bool {
+	course.EnsureScheduleFields()
+
+	if c.OpenOnly && course.Enrollment.SeatsRemaining == 0 {
+		return false
+	}
+	if c.MinRating > 0 && (!rating.Rated || rating.Quality < c.MinRating) {
+		return false
+	}
+	for _, meeting := range course.Meetings {
+		if c.EarliestStart != nil && meeting.StartMinutes < *c.EarliestStart {
+			return false
+		}
+		if c.LatestEnd != nil && meeting.EndMinutes > *c.LatestEnd {
+			return false
+		}
+		if slices.Contains(c.DaysOff, meeting.Weekday()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Candidate is a section the generator may pick.
+type Candidate struct {
+	Course types.Course
+	Rating Rating
+}
+
+// Component is one part of a course that every schedule must include, such as
+// its lecture or its lab. Sections of a course are split into components by
+// activity type, so a course with lecture and lab sections gets one of each.
+type Component struct {
+	CoursePrefix string      `json:"course_prefix"`
+	CourseNumber string      `json:"course_number"`
+	ActivityType string      `json:"activity_type"`
+	Candidates   []Candidate `json:"-"`
+}
+
+// Components groups a course's sections by activity type, in order of first
+// appearance.
+func Components(prefix, number string, candidates []Candidate) []Component {
+	var components []Component
+	index := make(map[string]int)
+	for _, candidate := range candidates {
+		key := strings.ToLower(strings.TrimSpace(candidate.Course.ActivityType))
+		i, ok := index[key]
+		if !ok {
+			i = len(components)
+			index[key] = i
+			components = append(components, Component{
+				CoursePrefix: prefix,
+				CourseNumber: number,
+				ActivityType: strings.TrimSpace(candidate.Course.ActivityType),
+			})
+		}
+		components[i].Candidates = append(components[i].Candidates, candidate)
+	}
+	return components
+}
+
+// ScheduledSection is a section picked for a schedule along with its rating.
+type ScheduledSection struct {
+	types.Course
+	Rating Rating `json:"rating"`
+}
+
+// Schedule is one conflict-free combination of sections.
+type Schedule struct {
+	Score        float64            `json:"score"`          // Mean instructor score of the sections (0-1)
+	DaysOnCampus int                `json:"days_on_campus"` // Distinct days with a meeting
+	IdleMinutes  int                `json:"idle_minutes"`   // Weekly time between meetings on the same day
+	Sections     []ScheduledSection `json:"sections"`
+}
+
+/*
+Generate returns up to limit conflict-free schedules that take one section from
+every component, best first.
+
+Schedules are ranked by the mean score of their sections' instructors, then by
+fewer days on campus, then by less idle time between classes. At most
+maxCombinations schedules are considered and at most maxConflictChecks pairs of
+sections compared; the second result reports whether either bound cut the
+search short. Components are searched from the fewest candidates to the most,
+and each component's candidates from best rated down, so a truncated search
+still favours the better schedules. Picking a section narrows every later
+component to the candidates that fit with it, and a branch that leaves some
+component without candidates is abandoned.
+*/
+func Generate(components []Component, limit int) ([]Schedule, bool) {
+	return generate(components, limit, maxConflictChecks)
+}
+
+func generate(components []Component, limit, checkBudget int) ([]Schedule, bool) {
+	if len(components) == 0 {
+		return []Schedule{}, false
+	}
+
+	ordered := make([]Component, len(components))
+	for i, component := range components {
+		component.Candidates = slices.Clone(component.Candidates)
+		for j := range component.Candidates {
+			component.Candidates[j].Course.EnsureScheduleFields()
+		}
+		slices.SortStableFunc(component.Candidates, func(a, b Candidate) int {
+			return cmp.Compare(b.Rating.score(), a.Rating.score())
+		})
+		ordered[i] = component
+	}
+	slices.SortStableFunc(ordered, func(a, b Component) int {
+		return cmp.Compare(len(a.Candidates), len(b.Candidates))
+	})
+
+	var (
+		schedules []Schedule
+		chosen    = make([]Candidate, 0, len(ordered))
+		truncated bool
+		checks    int
+	)
+
+	// narrow returns the later components' candidates that fit with candidate,
+	// or false when a component is left without any.
+	narrow := func(candidate Candidate, later [][]Candidate) ([][]Candidate, bool) {
+		next := make([][]Candidate, len(later))
+		for i, candidates := range later {
+			for _, other := range candidates {
+				if checks == checkBudget {
+					truncated = true
+					return nil, false
+				}
+				checks++
+				if len(Overlaps(candidate.Course, other.Course)) == 0 {
+					next[i] = append(next[i], other)
+				}
+			}
+			if len(next[i]) == 0 {
+				return nil, false
+			}
+		}
+		return next, true
+	}
+
+	// remaining holds the candidates of the components from depth on that fit
+	// with every section chosen so far.
+	var search func(depth int, remaining [][]Candidate)
+	search = func(depth int, remaining [][]Candidate) {
+		if depth == len(ordered) {
+			if len(schedules) == maxCombinations {
+				truncated = true
+				return
+			}
+			schedules = append(schedules, newSchedule(chosen))
+			return
+		}
+		for _, candidate := range remaining[0] {
+			next, ok := narrow(candidate, remaining[1:])
+			if truncated {
+				return
+			}
+			if !ok {
+				continue
+			}
+			chosen = append(chosen, candidate)
+			search(depth+1, next)
+			chosen = chosen[:len(chosen)-1]
+			if truncated {
+				return
+			}
+		}
+	}
+
+	remaining := make([][]Candidate, len(ordered))
+	for i, component := range ordered {
+		remaining[i] = component.Candidates
+	}
+	search(0, remaining)
+
+	slices.SortStableFunc(schedules, compareSchedules)
+	if limit > 0 && len(schedules) > limit {
+		schedules = schedules[:limit]
+	}
+	if schedules == nil {
+		schedules = []Schedule{}
+	}
+	return schedules, truncated
+}
+
+func newSchedule(chosen []Candidate) Schedule {
+	schedule := Schedule{Sections: make([]ScheduledSection, len(chosen))}
+
+	byDay := make(map[string][]types.Meeting)
+	total := 0.0
+	for i, candidate := range chosen {
+		schedule.Sections[i] = ScheduledSection{Course: candidate.Course, Rating: candidate.Rating}
+		total += candidate.Rating.score()
+		for _, meeting := range candidate.Course.Meetings {
+			byDay[meeting.Day] = append(byDay[meeting.Day], meeting)
+		}
+	}
+	schedule.Score = total / float64(len(chosen))
+	schedule.DaysOnCampus = len(byDay)
+
+	for _, meetings := range byDay {
+		slices.SortFunc(meetings, func(a, b types.Meeting) int {
+			return cmp.Compare(a.StartMinutes, b.StartMinutes)
+		})
+		for i := 1; i < len(meetings); i++ {
+			if gap := meetings[i].StartMinutes - meetings[i-1].EndMinutes; gap > 0 {
+				schedule.IdleMinutes += gap
+			}
+		}
+	}
+
+	slices.SortFunc(schedule.Sections, func(a, b ScheduledSection) int {
+		return strings.Compare(a.SectionAddress, b.SectionAddress)
+	})
+	return schedule
+}
+
+func compareSchedules(a, b Schedule) int {
+	if c := cmp.Compare(b.Score, a.Score); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.DaysOnCampus, b.DaysOnCampus); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.IdleMinutes, b.IdleMinutes); c != 0 {
+		return c
+	}
+	for i := range a.Sections {
+		if c := strings.Compare(a.Sections[i].SectionAddress, b.Sections[i].SectionAddress); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package schedule
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// component builds a component of n sections that each meet on Monday from
+// start to end minutes.
+func component(number string, n, start, end int) Component {
+	candidates := make([]Candidate, n)
+	for i := range candidates {
+		candidates[i] = Candidate{Course: types.Course{
+			SectionAddress: fmt.Sprintf("cs%s.%03d.24f", number, i+1),
+			CoursePrefix:   "cs",
+			CourseNumber:   number,
+			Section:        fmt.Sprintf("%03d", i+1),
+			Meetings:       []types.Meeting{{Day: "monday", StartMinutes: start, EndMinutes: end}},
+		}}
+	}
+	return Component{CoursePrefix: "cs", CourseNumber: number, Candidates: candidates}
+}
+
+func TestGenerateCombinesCompatibleSections(t *testing.T) {
+	components := []Component{
+		component("1337", 2, 8*60, 9*60),
+		component("2336", 3, 9*60, 10*60),
+	}
+
+	schedules, truncated := Generate(components, 0)
+	if truncated {
+		t.Fatal("truncated = true, want false")
+	}
+	if len(schedules) != 6 {
+		t.Fatalf("got %d schedules, want 6", len(schedules))
+	}
+	for _, s := range schedules {
+		if len(s.Sections) != 2 {
+			t.Fatalf("schedule has %d sections, want 2", len(s.Sections))
+		}
+	}
+}
+
+// A component that conflicts with every other one must end the search early
+// instead of walking every combination of the others.
+func TestGenerateUnsatisfiableComponent(t *testing.T) {
+	var components []Component
+	for i := range 5 {
+		start := (8 + i) * 60
+		components = append(components, component(fmt.Sprintf("%d", 3000+i), 20, start, start+50))
+	}
+	components = append(components, component("4000", 21, 8*60, 14*60))
+
+	begin := time.Now()
+	schedules, truncated := Generate(components, 10)
+	elapsed := time.Since(begin)
+
+	if len(schedules) != 0 {
+		t.Errorf("got %d schedules, want 0", len(schedules))
+	}
+	if truncated {
+		t.Error("truncated = true, want the search to finish within its budget")
+	}
+	if elapsed > time.Second {
+		t.Errorf("Generate took %s", elapsed)
+	}
+}
+
+func TestGenerateStopsAtCheckBudget(t *testing.T) {
+	components := []Component{
+		component("1337", 10, 8*60, 9*60),
+		component("2336", 10, 9*60, 10*60),
+		component("3345", 10, 10*60, 11*60),
+	}
+
+	schedules, truncated := generate(components, 0, 50)
+	if !truncated {
+		t.Error("truncated = false, want true once the budget runs out")
+	}
+	if len(schedules) == 0 || len(schedules) >= 1000 {
+		t.Errorf("got %d schedules, want a partial result", len(schedules))
+	}
+}
@@ -0,0 +1,230 @@
+package schedule
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // Calendar times are in America/Chicago even where the host has no zoneinfo.
+	"unicode"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// CalendarTimeZone is the zone every class meets in.
+const CalendarTimeZone = "America/Chicago"
+
+// icsDayCodes are the RFC 5545 BYDAY codes indexed by time.Weekday.
+var icsDayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// icsTimeZone describes America/Chicago for calendar apps that do not know it
+// by name, using the US daylight saving rules in effect since 2007.
+const icsTimeZone = `BEGIN:VTIMEZONE
+TZID:America/Chicago
+BEGIN:DAYLIGHT
+TZOFFSETFROM:-0600
+TZOFFSETTO:-0500
+TZNAME:CDT
+DTSTART:19700308T020000
+RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU
+END:DAYLIGHT
+BEGIN:STANDARD
+TZOFFSETFROM:-0500
+TZOFFSETTO:-0600
+TZNAME:CST
+DTSTART:19701101T020000
+RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU
+END:STANDARD
+END:VTIMEZONE`
+
+// meetingPattern is a set of days on which a section meets at the same time
+// and place; each pattern becomes one recurring event.
+type meetingPattern struct {
+	days         []time.Weekday
+	startMinutes int
+	endMinutes   int
+	location     string
+}
+
+/*
+Calendar renders sections as an iCalendar (RFC 5545) document.
+
+Every distinct meeting time and place of a section becomes a VEVENT repeating
+weekly on its days from the term's first day of classes through its last, in
+America/Chicago. Events carry the section's location, and its instructors and
+class number in the description. Sections without meeting times are skipped.
+stamp is recorded as each event's DTSTAMP.
+*/
+func Calendar(term types.Term, courses []types.Course, stamp time.Time) (string, error) {
+	zone, err := time.LoadLocation(CalendarTimeZone)
+	if err != nil {
+		return "", fmt.Errorf("failed to load calendar time zone: %w", err)
+	}
+	start, err := time.ParseInLocation("2006-01-02", term.StartDate, zone)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse term start date: %w", err)
+	}
+	end, err := time.ParseInLocation("2006-01-02", term.EndDate, zone)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse term end date: %w", err)
+	}
+	until := end.Add(24*time.Hour - time.Second).UTC().Format("20060102T150405Z")
+
+	var b strings.Builder
+	writeLine(&b, "BEGIN:VCALENDAR")
+	writeLine(&b, "VERSION:2.0")
+	writeLine(&b, "PRODID:-//ACM UTD//ACM API//EN")
+	writeLine(&b, "CALSCALE:GREGORIAN")
+	writeLine(&b, "METHOD:PUBLISH")
+	writeLine(&b, "X-WR-CALNAME:"+escapeText(term.Name+" Classes"))
+	writeLine(&b, "X-WR-TIMEZONE:"+CalendarTimeZone)
+	for _, line := range strings.Split(icsTimeZone, "\n") {
+		writeLine(&b, line)
+	}
+
+	for _, course := range courses {
+		course.EnsureScheduleFields()
+		for _, pattern := range meetingPatterns(course.Meetings) {
+			first, ok := firstMeetingDate(start, end, pattern.days)
+			if !ok {
+				continue
+			}
+			eventStart := atMinutes(first, pattern.startMinutes)
+			eventEnd := atMinutes(first, pattern.endMinutes)
+
+			byDay := make([]string, len(pattern.days))
+			for i, day := range pattern.days {
+				byDay[i] = icsDayCodes[day]
+			}
+
+			writeLine(&b, "BEGIN:VEVENT")
+			writeLine(&b, "UID:"+eventUID(course, pattern, byDay))
+			writeLine(&b, "DTSTAMP:"+stamp.UTC().Format("20060102T150405Z"))
+			writeLine(&b, "DTSTART;TZID="+CalendarTimeZone+":"+eventStart.Format("20060102T150405"))
+			writeLine(&b, "DTEND;TZID="+CalendarTimeZone+":"+eventEnd.Format("20060102T150405"))
+			writeLine(&b, "RRULE:FREQ=WEEKLY;BYDAY="+strings.Join(byDay, ",")+";UNTIL="+until)
+			writeLine(&b, "SUMMARY:"+escapeText(eventSummary(course)))
+			if pattern.location != "" {
+				writeLine(&b, "LOCATION:"+escapeText(pattern.location))
+			}
+			if description := eventDescription(course); description != "" {
+				writeLine(&b, "DESCRIPTION:"+escapeText(description))
+			}
+			writeLine(&b, "END:VEVENT")
+		}
+	}
+
+	writeLine(&b, "END:VCALENDAR")
+	return b.String(), nil
+}
+
+// meetingPatterns groups a section's meetings by time and place, ordered by
+// their first day of the week.
+func meetingPatterns(meetings []types.Meeting) []meetingPattern {
+	var patterns []meetingPattern
+	for _, meeting := range meetings {
+		location := strings.TrimSpace(meeting.Building + " " + meeting.Room)
+		i := slices.IndexFunc(patterns, func(p meetingPattern) bool {
+			return p.startMinutes == meeting.StartMinutes && p.endMinutes == meeting.EndMinutes && p.location == location
+		})
+		if i < 0 {
+			patterns = append(patterns, meetingPattern{startMinutes: meeting.StartMinutes, endMinutes: meeting.EndMinutes, location: location})
+			i = len(patterns) - 1
+		}
+		if day := meeting.Weekday(); !slices.Contains(patterns[i].days, day) {
+			patterns[i].days = append(patterns[i].days, day)
+		}
+	}
+
+	for i := range patterns {
+		slices.Sort(patterns[i].days)
+	}
+	slices.SortStableFunc(patterns, func(a, b meetingPattern) int {
+		return cmp.Compare(a.days[0], b.days[0])
+	})
+	return patterns
+}
+
+// firstMeetingDate returns the first day from start through end that falls on
+// one of the days.
+func firstMeetingDate(start, end time.Time, days []time.Weekday) (time.Time, bool) {
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		if slices.Contains(days, date.Weekday()) {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// atMinutes returns the wall-clock time minutes after midnight on date.
+func atMinutes(date time.Time, minutes int) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), minutes/60, minutes%60, 0, 0, date.Location())
+}
+
+// eventUID identifies a meeting pattern of a section. Patterns only differ by
+// days, time, or place, so the place is included for sections that meet in two
+// rooms at once.
+func eventUID(course types.Course, pattern meetingPattern, byDay []string) string {
+	uid := fmt.Sprintf("%s-%s-%04d", course.SectionAddress, strings.ToLower(strings.Join(byDay, "")), pattern.startMinutes)
+	if place := uidPlace(pattern.location); place != "" {
+		uid += "-" + place
+	}
+	return uid + "@acmutd-api"
+}
+
+// uidPlace reduces a location such as "ECSS 2.415" to "ecss2415".
+func uidPlace(location string) string {
+	return strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, location)
+}
+
+func eventSummary(course types.Course) string {
+	summary := fmt.Sprintf("%s %s.%s", strings.ToUpper(course.CoursePrefix), strings.ToUpper(course.CourseNumber), course.Section)
+	if title := strings.TrimSpace(course.Title); title != "" {
+		summary += " " + title
+	}
+	return summary
+}
+
+func eventDescription(course types.Course) string {
+	var lines []string
+	if instructors := types.SplitList(course.Instructors); len(instructors) > 0 {
+		lines = append(lines, "Instructors: "+strings.Join(instructors, ", "))
+	}
+	if activity := strings.TrimSpace(course.ActivityType); activity != "" {
+		lines = append(lines, "Activity: "+activity)
+	}
+	if classNumber := strings.TrimSpace(course.ClassNumber); classNumber != "" {
+		lines = append(lines, "Class number: "+classNumber)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escapeText escapes an RFC 5545 TEXT value.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// writeLine writes a content line terminated by CRLF, folding it so no line
+// exceeds 75 octets without splitting a UTF-8 character.
+func writeLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = 74
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
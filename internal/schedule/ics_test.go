@@ -0,0 +1,47 @@
+package schedule
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+func TestCalendarUIDsDistinguishRooms(t *testing.T) {
+	term, err := types.ParseTerm("24f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	course := types.Course{
+		SectionAddress: "cs1136.101.24f",
+		CoursePrefix:   "cs",
+		CourseNumber:   "1136",
+		Section:        "101",
+		Meetings: []types.Meeting{
+			{Day: "monday", StartMinutes: 600, EndMinutes: 710, Building: "ECSS", Room: "2.103"},
+			{Day: "monday", StartMinutes: 600, EndMinutes: 710, Building: "ECSS", Room: "2.104"},
+		},
+	}
+
+	calendar, err := Calendar(term, []types.Course{course}, time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uids := make(map[string]bool)
+	for _, line := range strings.Split(calendar, "\r\n") {
+		if uid, ok := strings.CutPrefix(line, "UID:"); ok {
+			if uids[uid] {
+				t.Errorf("duplicate UID %s", uid)
+			}
+			uids[uid] = true
+		}
+	}
+	if len(uids) != 2 {
+		t.Fatalf("got %d events, want 2", len(uids))
+	}
+	if !uids["cs1136.101.24f-mo-0600-ecss2103@acmutd-api"] {
+		t.Errorf("UIDs %v do not include the ECSS 2.103 meeting", uids)
+	}
+}
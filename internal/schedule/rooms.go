@@ -0,0 +1,158 @@
+package schedule
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// Room is a classroom that hosts at least one meeting in a term.
+type Room struct {
+	Building      string `json:"building"`
+	Room          string `json:"room"`
+	SectionCount  int    `json:"section_count"`  // Sections meeting in the room
+	WeeklyMinutes int    `json:"weekly_minutes"` // Scheduled meeting time per week
+}
+
+// Booking is one weekly meeting held in a room.
+type Booking struct {
+	Day            string `json:"day"`
+	StartMinutes   int    `json:"start_minutes"`
+	EndMinutes     int    `json:"end_minutes"`
+	SectionAddress string `json:"section_address"`
+	CoursePrefix   string `json:"course_prefix"`
+	CourseNumber   string `json:"course_number"`
+	Section        string `json:"section"`
+	Title          string `json:"title"`
+	Instructors    string `json:"instructors"`
+	ActivityType   string `json:"activity_type"`
+}
+
+// Occupancy indexes a term's meetings by room.
+type Occupancy struct {
+	rooms    []Room
+	bookings map[roomKey][]Booking
+}
+
+type roomKey struct {
+	building string
+	room     string
+}
+
+func newRoomKey(building, room string) roomKey {
+	return roomKey{strings.ToUpper(strings.TrimSpace(building)), strings.ToUpper(strings.TrimSpace(room))}
+}
+
+// NewOccupancy collects the meetings of every section by room. Meetings
+// without a room number, such as "ONLINE" or "TBA", are left out.
+func NewOccupancy(courses []types.Course) *Occupancy {
+	occupancy := &Occupancy{bookings: make(map[roomKey][]Booking)}
+	names := make(map[roomKey]Room)
+	sections := make(map[roomKey]map[string]bool)
+	minutes := make(map[roomKey]int)
+
+	for _, course := range courses {
+		course.EnsureScheduleFields()
+		for _, meeting := range course.Meetings {
+			if meeting.Building == "" || meeting.Room == "" {
+				continue
+			}
+			key := newRoomKey(meeting.Building, meeting.Room)
+			if _, ok := names[key]; !ok {
+				names[key] = Room{Building: meeting.Building, Room: meeting.Room}
+				sections[key] = make(map[string]bool)
+			}
+			sections[key][course.SectionAddress] = true
+			minutes[key] += meeting.EndMinutes - meeting.StartMinutes
+			occupancy.bookings[key] = append(occupancy.bookings[key], Booking{
+				Day:            meeting.Day,
+				StartMinutes:   meeting.StartMinutes,
+				EndMinutes:     meeting.EndMinutes,
+				SectionAddress: course.SectionAddress,
+				CoursePrefix:   course.CoursePrefix,
+				CourseNumber:   course.CourseNumber,
+				Section:        course.Section,
+				Title:          course.Title,
+				Instructors:    course.Instructors,
+				ActivityType:   course.ActivityType,
+			})
+		}
+	}
+
+	for key, room := range names {
+		room.SectionCount = len(sections[key])
+		room.WeeklyMinutes = minutes[key]
+		occupancy.rooms = append(occupancy.rooms, room)
+
+		slices.SortFunc(occupancy.bookings[key], func(a, b Booking) int {
+			if c := cmp.Compare(dayIndex(a.Day), dayIndex(b.Day)); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.StartMinutes, b.StartMinutes); c != 0 {
+				return c
+			}
+			return strings.Compare(a.SectionAddress, b.SectionAddress)
+		})
+	}
+	slices.SortFunc(occupancy.rooms, compareRooms)
+
+	return occupancy
+}
+
+// dayIndex orders weekdays Monday first, the way a class week reads.
+func dayIndex(day string) int {
+	weekday := types.Meeting{Day: day}.Weekday()
+	return (int(weekday) + 6) % 7
+}
+
+func compareRooms(a, b Room) int {
+	if c := strings.Compare(a.Building, b.Building); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Room, b.Room)
+}
+
+// Rooms lists the rooms in use, ordered by building and room. A non-empty
+// building limits the list to that building.
+func (o *Occupancy) Rooms(building string) []Room {
+	rooms := []Room{}
+	for _, room := range o.rooms {
+		if building == "" || strings.EqualFold(room.Building, strings.TrimSpace(building)) {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms
+}
+
+// Schedule returns a room's weekly meetings, Monday first, and reports whether
+// the room hosts any meetings in the term.
+func (o *Occupancy) Schedule(building, room string) (Room, []Booking, bool) {
+	key := newRoomKey(building, room)
+	bookings, ok := o.bookings[key]
+	if !ok {
+		return Room{}, nil, false
+	}
+	i := slices.IndexFunc(o.rooms, func(r Room) bool { return newRoomKey(r.Building, r.Room) == key })
+	return o.rooms[i], bookings, true
+}
+
+// FreeRooms lists the rooms with no meeting overlapping start to end (minutes
+// after midnight) on the given day. A non-empty building limits the search to
+// that building. Only rooms that host a meeting sometime in the term are
+// known, so rooms never scheduled for classes are not reported.
+func (o *Occupancy) FreeRooms(day time.Weekday, start, end int, building string) []Room {
+	dayName := strings.ToLower(day.String())
+	free := []Room{}
+	for _, room := range o.Rooms(building) {
+		busy := slices.ContainsFunc(o.bookings[newRoomKey(room.Building, room.Room)], func(b Booking) bool {
+			return b.Day == dayName && b.StartMinutes < end && start < b.EndMinutes
+		})
+		if !busy {
+			free = append(free, room)
+		}
+	}
+	return free
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/ingest"
 )
 
 type CoursebookHandler struct {
@@ -66,3 +68,16 @@ func (h *CoursebookHandler) uploadJSONFile(outputDir, fileName string) error {
 	log.Printf("Successfully uploaded file: %s to cloud storage at path: %s", fileName, cloudPath)
 	return nil
 }
+
+// Ingest parses the coursebook output and writes the sections into the Firestore course collections
+func (h *CoursebookHandler) Ingest(scraper string) error {
+	outputDir := "scripts/" + scraper + "/out"
+	report, err := ingest.IngestCoursebook(context.Background(), h.service.firestoreClient, outputDir)
+	if report != nil {
+		report.Log()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to ingest coursebook data: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/ingest"
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/backend"
+)
+
+// IngestHandler loads scraper output already on disk into the configured
+// storage backend without re-running any scrapers.
+type IngestHandler struct {
+	service         *ScraperService
+	coursebookDir   string
+	gradesDir       string
+	professorsFile  string
+	pruneProfessors bool
+}
+
+func NewIngestHandler(service *ScraperService) (*IngestHandler, error) {
+	if service == nil {
+		return nil, errors.New("scraper service is required")
+	}
+
+	handler := &IngestHandler{
+		service:        service,
+		coursebookDir:  filepath.Join("scripts", "coursebook", "out"),
+		gradesDir:      filepath.Join("scripts", "integration", "out", "grades"),
+		professorsFile: filepath.Join("scripts", "integration", "out", "professors", "matched_professor_data.json"),
+	}
+
+	if dir := strings.TrimSpace(os.Getenv("INGEST_COURSEBOOK_DIR")); dir != "" {
+		handler.coursebookDir = dir
+	}
+	if dir := strings.TrimSpace(os.Getenv("INGEST_GRADES_DIR")); dir != "" {
+		handler.gradesDir = dir
+	}
+	if file := strings.TrimSpace(os.Getenv("INGEST_PROFESSORS_FILE")); file != "" {
+		handler.professorsFile = file
+	}
+
+	prune, err := parseBoolEnv("INGEST_PRUNE_PROFESSORS", false)
+	if err != nil {
+		return nil, err
+	}
+	handler.pruneProfessors = prune
+
+	return handler, nil
+}
+
+// IngestStart writes local scraper output into the backend selected by STORAGE_BACKEND.
+// Sources whose output does not exist are skipped.
+func (h *IngestHandler) IngestStart() error {
+	ctx := context.Background()
+	_, writer, err := openIngestBackend(ctx)
+	if err != nil {
+		return err
+	}
+
+	sources := []struct {
+		name string
+		path string
+		run  func() error
+	}{
+		{"coursebook", h.coursebookDir, func() error {
+			report, err := ingest.IngestCoursebook(ctx, writer, h.coursebookDir)
+			if report != nil {
+				report.Log()
+			}
+			return err
+		}},
+		{"grades", h.gradesDir, func() error {
+			report, err := ingest.IngestGrades(ctx, writer, h.gradesDir)
+			if report != nil {
+				report.Log()
+			}
+			return err
+		}},
+		{"professors", h.professorsFile, func() error {
+			report, err := ingest.IngestProfessors(ctx, writer, h.professorsFile, h.pruneProfessors)
+			if report != nil {
+				report.Log()
+			}
+			return err
+		}},
+	}
+
+	ingested := 0
+	for _, source := range sources {
+		if _, err := os.Stat(source.path); os.IsNotExist(err) {
+			log.Printf("Skipping %s: %s does not exist", source.name, source.path)
+			continue
+		}
+
+		log.Printf("Ingesting %s data from %s into %s", source.name, source.path, backend.Name())
+		if err := source.run(); err != nil {
+			return fmt.Errorf("failed to ingest %s data: %w", source.name, err)
+		}
+		ingested++
+	}
+
+	if ingested == 0 {
+		return errors.New("no scraper output found to ingest")
+	}
+
+	return nil
+}
+
+// RefreshStart rewrites the sections already stored in the backend selected by
+// STORAGE_BACKEND so they pick up fields added to the stored layout since they
+// were ingested. It reads no scraper output.
+func (h *IngestHandler) RefreshStart() error {
+	ctx := context.Background()
+	store, writer, err := openIngestBackend(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Refreshing stored courses in %s", backend.Name())
+	report, err := ingest.RefreshCourses(ctx, store, writer)
+	if report != nil {
+		report.Log()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to refresh courses: %w", err)
+	}
+	return nil
+}
+
+// openIngestBackend opens the persistent backend selected by STORAGE_BACKEND.
+func openIngestBackend(ctx context.Context) (storage.Store, storage.Writer, error) {
+	if backend.Name() == backend.Memory {
+		return nil, nil, errors.New("STORAGE_BACKEND=memory does not persist data; use 'firestore' or 'sqlite' for ingestion")
+	}
+
+	store, err := backend.Open(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open storage backend: %w", err)
+	}
+
+	writer, ok := store.(storage.Writer)
+	if !ok {
+		return nil, nil, fmt.Errorf("storage backend %s does not support ingestion", backend.Name())
+	}
+	return store, writer, nil
+}
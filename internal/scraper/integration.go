@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+
+	"github.com/acmutd/acmutd-api/internal/ingest"
 )
 
 var scrapers = []string{"coursebook", "grades", "rmp-profiles"}
@@ -24,6 +26,7 @@ type IntegrationConfig struct {
 	Source          string
 	ShouldRescrape  bool
 	SaveEnvironment string
+	PruneProfessors bool
 }
 
 type IntegrationDirectories struct {
@@ -69,13 +72,19 @@ func (s *IntegrationHandler) loadEnvConfig() error {
 	}
 
 	if rescrape := os.Getenv("INTEGRATION_RESCRAPE"); rescrape != "" {
-		value, err := s.parseBoolEnv("INTEGRATION_RESCRAPE", s.config.ShouldRescrape)
+		value, err := parseBoolEnv("INTEGRATION_RESCRAPE", s.config.ShouldRescrape)
 		if err != nil {
 			return err
 		}
 		s.config.ShouldRescrape = value
 	}
 
+	pruneProfessors, err := parseBoolEnv("INGEST_PRUNE_PROFESSORS", s.config.PruneProfessors)
+	if err != nil {
+		return err
+	}
+	s.config.PruneProfessors = pruneProfessors
+
 	switch s.config.Source {
 	case "local", "dev", "prod":
 		// valid
@@ -145,22 +154,6 @@ func (s *IntegrationHandler) IntegrationStart() error {
 	return nil
 }
 
-// parseBoolEnv parses a boolean environment variable with a default value
-func (s *IntegrationHandler) parseBoolEnv(key string, defaultValue bool) (bool, error) {
-	value := strings.ToLower(os.Getenv(key))
-	if value == "" {
-		return defaultValue, nil
-	}
-	switch value {
-	case "true":
-		return true, nil
-	case "false":
-		return false, nil
-	default:
-		return false, fmt.Errorf("invalid %s: %s (must be 'true' or 'false')", key, value)
-	}
-}
-
 // gatherInputData collects data from local files or Firebase based on INTEGRATION_SOURCE
 func (s *IntegrationHandler) gatherInputData() error {
 	log.Printf("Data source: %s", s.config.Source)
@@ -494,7 +487,13 @@ func (s *IntegrationHandler) uploadAllScrapers() error {
 	}
 
 	jobs := []uploadJob{
-		{"coursebook", func() error { return NewCoursebookHandler(s.service).Upload("coursebook") }},
+		{"coursebook", func() error {
+			handler := NewCoursebookHandler(s.service)
+			if err := handler.Upload("coursebook"); err != nil {
+				return err
+			}
+			return handler.Ingest("coursebook")
+		}},
 		{"grades", func() error { return NewGradesHandler(s.service).Upload("grades") }},
 		{"rmp-profiles", func() error { return NewRMPProfilesHandler(s.service).Upload("rmp-profiles") }},
 	}
@@ -602,6 +601,17 @@ func (s *IntegrationHandler) uploadIntegrationResults() error {
 	}
 	log.Println("✓ Enhanced grades uploaded")
 
+	// Load grades into the grades/{prefix}/courses/{number}/records tree
+	log.Println("Loading enhanced grades into Firestore...")
+	report, err := ingest.IngestGrades(context.Background(), s.service.firestoreClient, gradesDir)
+	if report != nil {
+		report.Log()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load grades: %w", err)
+	}
+	log.Println("✓ Enhanced grades loaded")
+
 	// Upload professors directory
 	log.Println("Uploading matched professor data...")
 	if err := s.uploadDirectory(professorsDir, "professors"); err != nil {
@@ -609,6 +619,18 @@ func (s *IntegrationHandler) uploadIntegrationResults() error {
 	}
 	log.Println("✓ Professor data uploaded")
 
+	// Load professors into the professors/{instructor_id} collection
+	log.Println("Loading matched professor data into Firestore...")
+	professorsFile := filepath.Join(professorsDir, "matched_professor_data.json")
+	professorReport, err := ingest.IngestProfessors(context.Background(), s.service.firestoreClient, professorsFile, s.config.PruneProfessors)
+	if professorReport != nil {
+		professorReport.Log()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load professors: %w", err)
+	}
+	log.Println("✓ Professor data loaded")
+
 	return nil
 }
 
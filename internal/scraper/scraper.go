@@ -134,7 +134,11 @@ func (s *ScraperService) CheckAndRunScraper() error {
 	var uploadErr error
 	switch s.scraper {
 	case "coursebook":
-		uploadErr = NewCoursebookHandler(s).Upload(s.scraper)
+		handler := NewCoursebookHandler(s)
+		uploadErr = handler.Upload(s.scraper)
+		if uploadErr == nil {
+			uploadErr = handler.Ingest(s.scraper)
+		}
 	case "grades":
 		uploadErr = NewGradesHandler(s).Upload(s.scraper)
 	case "rmp-profiles":
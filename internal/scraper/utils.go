@@ -1,8 +1,10 @@
 package scraper
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"strings"
 )
 
 // isOutputEmpty checks if the output directory is empty or doesn't exist
@@ -19,3 +21,19 @@ func (s *ScraperService) isOutputEmpty(outputDir string) bool {
 
 	return len(entries) == 0
 }
+
+// parseBoolEnv parses a boolean environment variable with a default value
+func parseBoolEnv(key string, defaultValue bool) (bool, error) {
+	value := strings.ToLower(os.Getenv(key))
+	if value == "" {
+		return defaultValue, nil
+	}
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid %s: %s (must be 'true' or 'false')", key, value)
+	}
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// courseSchema ranks a hit on a course code above a hit in a title, and a
+// title above an instructor name.
+var courseSchema = schema{
+	fields: []Field{FieldCourse, FieldClassNumber, FieldTitle, FieldTopic, FieldInstructors},
+	weights: map[Field]float64{
+		FieldCourse:      10,
+		FieldClassNumber: 10,
+		FieldTitle:       5,
+		FieldTopic:       3,
+		FieldInstructors: 2,
+	},
+	phrase:           []Field{FieldTitle, FieldTopic},
+	splitCourseCodes: true,
+}
+
+// CourseResult is a matched course section along with its relevance score and
+// the fields the query matched.
+type CourseResult struct {
+	types.Course
+	Score         float64 `json:"score"`
+	MatchedFields []Field `json:"matched_fields"`
+}
+
+// CourseIndex is an inverted index over the sections of a single term.
+type CourseIndex struct {
+	courses []types.Course
+	index   *index
+	schools []types.SchoolSummary
+}
+
+// NewCourseIndex tokenizes every section's title, topic, instructors, course
+// code, and class number.
+func NewCourseIndex(courses []types.Course) *CourseIndex {
+	sorted := make([]types.Course, len(courses))
+	copy(sorted, courses)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].SectionAddress < sorted[b].SectionAddress
+	})
+
+	docs := make([]map[Field]string, len(sorted))
+	for i, course := range sorted {
+		docs[i] = map[Field]string{
+			FieldCourse:      course.CoursePrefix + " " + course.CourseNumber,
+			FieldClassNumber: course.ClassNumber,
+			FieldTitle:       course.Title,
+			FieldTopic:       course.Topic,
+			FieldInstructors: course.Instructors,
+		}
+	}
+
+	return &CourseIndex{courses: sorted, index: newIndex(courseSchema, docs), schools: summarizeSchools(sorted)}
+}
+
+// Len reports how many sections the index holds.
+func (i *CourseIndex) Len() int {
+	return len(i.courses)
+}
+
+// Schools summarizes the schools offering the indexed sections. The summary is
+// computed once, when the index is built.
+func (i *CourseIndex) Schools() []types.SchoolSummary {
+	return i.schools
+}
+
+// Search returns the sections matching every query token, best match first.
+// Ties are ordered by section address so results are stable across pages.
+func (i *CourseIndex) Search(query string) []CourseResult {
+	matches := i.index.search(query)
+	results := make([]CourseResult, len(matches))
+	for n, m := range matches {
+		results[n] = CourseResult{Course: i.courses[m.doc], Score: m.score, MatchedFields: m.fields}
+	}
+	return results
+}
+
+// Filter returns the sections matching the filter, ordered by section address.
+func (i *CourseIndex) Filter(filter CourseFilter) []types.Course {
+	courses := []types.Course{}
+	for _, course := range i.courses {
+		if filter.Matches(course) {
+			courses = append(courses, course)
+		}
+	}
+	return courses
+}
+
+// FilterResults keeps the search results matching the filter, in rank order.
+func FilterResults(results []CourseResult, filter CourseFilter) []CourseResult {
+	if filter.IsZero() {
+		return results
+	}
+	filtered := []CourseResult{}
+	for _, result := range results {
+		if filter.Matches(result.Course) {
+			filtered = append(filtered, result)
+		}
+	}
+	return filtered
+}
+
+// summarizeSchools counts sections per school and per department within each
+// school, sorted by name. Sections without a school are skipped; sections
+// without a department count toward their school only.
+func summarizeSchools(courses []types.Course) []types.SchoolSummary {
+	schools := make(map[string]*types.SchoolSummary)
+	departments := make(map[string]map[string]int)
+
+	for _, course := range courses {
+		school := strings.TrimSpace(course.School.String())
+		if school == "" {
+			continue
+		}
+
+		summary, ok := schools[school]
+		if !ok {
+			summary = &types.SchoolSummary{School: school}
+			schools[school] = summary
+			departments[school] = make(map[string]int)
+		}
+		summary.SectionCount++
+
+		if dept := strings.TrimSpace(course.Dept); dept != "" {
+			departments[school][dept]++
+		}
+	}
+	summaries := make([]types.SchoolSummary, 0, len(schools))
+	for school, summary := range schools {
+		summary.Departments = make([]types.DepartmentSummary, 0, len(departments[school]))
+		for dept, count := range departments[school] {
+			summary.Departments = append(summary.Departments, types.DepartmentSummary{Department: dept, SectionCount: count})
+		}
+		sort.Slice(summary.Departments, func(i, j int) bool {
+			return summary.Departments[i].Department < summary.Departments[j].Department
+		})
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].School < summaries[j].School
+	})
+
+	return summaries
+}
@@ -0,0 +1,110 @@
+package search
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+func newTestCourseIndex() *CourseIndex {
+	return NewCourseIndex([]types.Course{
+		{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", ClassNumber: "81234", Title: "Data Structures and Introduction to Algorithmic Analysis", Instructors: "Jane Doe"},
+		{SectionAddress: "cs3354.001.24f", CoursePrefix: "cs", CourseNumber: "3354", Title: "Software Engineering"},
+		{SectionAddress: "cs4349.001.24f", CoursePrefix: "cs", CourseNumber: "4349", Title: "Advanced Algorithm Design and Analysis"},
+		{SectionAddress: "cs6363.001.24f", CoursePrefix: "cs", CourseNumber: "6363", Title: "Design and Analysis of Computer Algorithms"},
+		{SectionAddress: "math3345.001.24f", CoursePrefix: "math", CourseNumber: "3345", Title: "Abstract Algebra"},
+	})
+}
+
+func addresses(results []CourseResult) []string {
+	var out []string
+	for _, result := range results {
+		out = append(out, result.SectionAddress)
+	}
+	return out
+}
+
+func TestCourseSearch(t *testing.T) {
+	index := newTestCourseIndex()
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"spaced course code", "cs 3345", []string{"cs3345.001.24f"}},
+		{"run-together course code", "CS3345", []string{"cs3345.001.24f"}},
+		{"number prefix", "cs 33", []string{"cs3345.001.24f", "cs3354.001.24f"}},
+		{"number alone", "3345", []string{"cs3345.001.24f", "math3345.001.24f"}},
+		{"class number", "81234", []string{"cs3345.001.24f"}},
+		{"one typo", "algoritms", []string{"cs4349.001.24f", "cs6363.001.24f"}},
+		{"two typos", "algortihsm", []string{"cs4349.001.24f", "cs6363.001.24f"}},
+		{"word prefix", "algo", []string{"cs3345.001.24f", "cs4349.001.24f", "cs6363.001.24f"}},
+		{"every word must match", "software algorithms", nil},
+		{"instructor", "jane doe", []string{"cs3345.001.24f"}},
+		{"short words need exact matches", "cz 3345", nil},
+		{"empty", "  ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addresses(index.Search(tt.query))
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCourseSearchRanking(t *testing.T) {
+	index := newTestCourseIndex()
+
+	// The exact word outranks its typo-tolerant neighbour.
+	if results := index.Search("algorithms"); len(results) == 0 || results[0].SectionAddress != "cs6363.001.24f" {
+		t.Errorf("Search(algorithms) = %v, want cs6363 first", addresses(results))
+	}
+	if results := index.Search("algoritms"); len(results) == 0 || results[0].SectionAddress != "cs6363.001.24f" {
+		t.Errorf("Search(algoritms) = %v, want cs6363 first", addresses(results))
+	}
+	// A course code hit outranks a number that only appears in another prefix.
+	if results := index.Search("cs 3345"); len(results) != 1 || !slices.Equal(results[0].MatchedFields, []Field{FieldCourse}) {
+		t.Errorf("Search(cs 3345) = %+v, want cs3345 matched on its course code", results)
+	}
+}
+
+func TestCourseSearchTiesKeepAddressOrder(t *testing.T) {
+	index := NewCourseIndex([]types.Course{
+		{SectionAddress: "cs1000.001.24f", CoursePrefix: "cs", CourseNumber: "1000", Title: "Data Structures Seminar"},
+		{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", Title: "Data Structures"},
+	})
+
+	if got := addresses(index.Search("data structures")); !slices.Equal(got, []string{"cs1000.001.24f", "cs3345.001.24f"}) {
+		t.Errorf("tied title matches = %v, want section address order", got)
+	}
+}
+
+func TestCourseIndexSchools(t *testing.T) {
+	index := NewCourseIndex([]types.Course{
+		{SectionAddress: "cs1337.001.24f", School: "ECS", Dept: "Computer Science"},
+		{SectionAddress: "cs3345.001.24f", School: "ECS", Dept: "Computer Science"},
+		{SectionAddress: "ee2310.001.24f", School: "ECS", Dept: "Electrical Engineering"},
+		{SectionAddress: "ecs1100.001.24f", School: "ECS"},
+		{SectionAddress: "math2413.001.24f", School: "NSM", Dept: "Mathematics"},
+		{SectionAddress: "univ1010.001.24f"},
+	})
+
+	want := []types.SchoolSummary{
+		{School: "ECS", SectionCount: 4, Departments: []types.DepartmentSummary{
+			{Department: "Computer Science", SectionCount: 2},
+			{Department: "Electrical Engineering", SectionCount: 1},
+		}},
+		{School: "NSM", SectionCount: 1, Departments: []types.DepartmentSummary{
+			{Department: "Mathematics", SectionCount: 1},
+		}},
+	}
+	if got := index.Schools(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Schools() = %+v, want %+v", got, want)
+	}
+}
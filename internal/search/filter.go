@@ -0,0 +1,116 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// Modality separates sections taught online from those that meet in person.
+type Modality string
+
+const (
+	ModalityOnline   Modality = "online"
+	ModalityInPerson Modality = "in_person"
+)
+
+/*
+CourseFilter narrows a term's sections. Zero-valued fields do not filter, and
+every set field must match.
+
+Days, StartAfter, and EndBefore look at a section's meetings: every meeting
+must fall on one of Days and within the time window, so sections without
+scheduled meetings never match them. Text fields compare case-insensitively,
+except School, which matches the school code exactly as the storage listings do.
+*/
+type CourseFilter struct {
+	Prefix       string
+	Number       string
+	School       string
+	OpenOnly     bool           // Only sections with seats remaining
+	Days         []time.Weekday // Sections meeting only on these days
+	StartAfter   *int           // Minutes after midnight; nil places no bound
+	EndBefore    *int           // Minutes after midnight; nil places no bound
+	InstructorID string
+	ActivityType string
+	CoreArea     string
+	Dept         string
+	Session      string
+	Level        int // Leading digit of the course number, e.g. 3 for 3000-level
+	Modality     Modality
+}
+
+// IsZero reports whether the filter matches every section.
+func (f CourseFilter) IsZero() bool {
+	return f.Prefix == "" && f.Number == "" && f.School == "" && !f.OpenOnly &&
+		len(f.Days) == 0 && f.StartAfter == nil && f.EndBefore == nil && f.InstructorID == "" &&
+		f.ActivityType == "" && f.CoreArea == "" && f.Dept == "" && f.Session == "" &&
+		f.Level == 0 && f.Modality == ""
+}
+
+// Matches reports whether the section satisfies every field of the filter.
+func (f CourseFilter) Matches(course types.Course) bool {
+	course.EnsureScheduleFields()
+
+	switch {
+	case f.Prefix != "" && course.CoursePrefix != f.Prefix,
+		f.Number != "" && course.CourseNumber != f.Number,
+		f.School != "" && string(course.School) != f.School,
+		f.OpenOnly && course.Enrollment.SeatsRemaining == 0,
+		!equalFoldIfSet(f.ActivityType, course.ActivityType),
+		!equalFoldIfSet(f.CoreArea, course.CoreArea),
+		!equalFoldIfSet(f.Dept, course.Dept),
+		!equalFoldIfSet(f.Session, course.Session),
+		f.Level != 0 && courseLevel(course.CourseNumber) != f.Level,
+		f.Modality != "" && courseModality(course) != f.Modality:
+		return false
+	}
+
+	if f.InstructorID != "" && !slices.Contains(course.InstructorIDList, storage.NormalizeInstructorID(f.InstructorID)) {
+		return false
+	}
+
+	if len(f.Days) > 0 || f.StartAfter != nil || f.EndBefore != nil {
+		if len(course.Meetings) == 0 {
+			return false
+		}
+		for _, meeting := range course.Meetings {
+			if len(f.Days) > 0 && !slices.Contains(f.Days, meeting.Weekday()) {
+				return false
+			}
+			if f.StartAfter != nil && meeting.StartMinutes < *f.StartAfter {
+				return false
+			}
+			if f.EndBefore != nil && meeting.EndMinutes > *f.EndBefore {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func equalFoldIfSet(want, value string) bool {
+	return want == "" || strings.EqualFold(want, strings.TrimSpace(value))
+}
+
+// courseLevel returns the leading digit of a course number, or 0 when the
+// number does not start with one.
+func courseLevel(number string) int {
+	if number == "" || number[0] < '1' || number[0] > '9' {
+		return 0
+	}
+	return int(number[0] - '0')
+}
+
+// courseModality treats sections located "online" as online and every other
+// section, including those still awaiting a room, as in person.
+func courseModality(course types.Course) Modality {
+	if strings.Contains(strings.ToLower(course.Location), "online") {
+		return ModalityOnline
+	}
+	return ModalityInPerson
+}
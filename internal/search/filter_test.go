@@ -0,0 +1,32 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+func TestMatchesInstructorID(t *testing.T) {
+	ingested := types.Course{InstructorIDs: "JXD123456, abc000000", InstructorIDList: []string{"jxd123456", "abc000000"}}
+	// Sections stored before ingestion filled in instructor_id_list.
+	legacy := types.Course{InstructorIDs: "JXD123456, abc000000"}
+
+	tests := []struct {
+		name   string
+		course types.Course
+		id     string
+		want   bool
+	}{
+		{"ingested", ingested, "jxd123456", true},
+		{"ingested mixed case", ingested, " JxD123456 ", true},
+		{"legacy", legacy, "jxd123456", true},
+		{"legacy second instructor", legacy, "ABC000000", true},
+		{"other instructor", legacy, "zzz999999", false},
+		{"no instructors", types.Course{}, "jxd123456", false},
+	}
+	for _, tt := range tests {
+		if got := (CourseFilter{InstructorID: tt.id}).Matches(tt.course); got != tt.want {
+			t.Errorf("%s: Matches(instructor %q) = %v, want %v", tt.name, tt.id, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package search
+
+import "testing"
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"algorithms", "algorithms", 2, 0},
+		{"algoritms", "algorithms", 2, 1},   // deletion
+		{"algorithmss", "algorithms", 2, 1}, // insertion
+		{"algorithns", "algorithms", 2, 1},  // substitution
+		{"algorihtms", "algorithms", 2, 1},  // transposition
+		{"alogrithsm", "algorithms", 2, 2},
+		{"analysis", "algorithms", 2, 3}, // stops once over the limit
+		{"cs", "algorithms", 2, 3},       // length difference alone exceeds the limit
+		{"jnae", "jane", 1, 1},
+		{"", "ab", 2, 2},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b, tt.limit); got != tt.want {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", tt.a, tt.b, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMaxEdits(t *testing.T) {
+	tests := []struct {
+		token string
+		want  int
+	}{
+		{"cs", 0},
+		{"doe", 0},
+		{"jane", 1},
+		{"algebra", 1},
+		{"algoritms", 2},
+	}
+	for _, tt := range tests {
+		if got := maxEdits(tt.token); got != tt.want {
+			t.Errorf("maxEdits(%q) = %d, want %d", tt.token, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Field names a searchable part of an indexed document.
+type Field string
+
+const (
+	// Course section fields.
+	FieldCourse      Field = "course"
+	FieldClassNumber Field = "class_number"
+	FieldTitle       Field = "title"
+	FieldTopic       Field = "topic"
+	FieldInstructors Field = "instructors"
+
+	// Professor fields.
+	FieldInstructorID Field = "instructor_id"
+	FieldName         Field = "name"
+	FieldDepartment   Field = "department"
+)
+
+const (
+	// prefixMatchWeight discounts a query token that only starts an indexed token.
+	prefixMatchWeight = 0.5
+	// fuzzyMatchWeight discounts a query token that only matches with typos; it
+	// is divided by the number of edits.
+	fuzzyMatchWeight = 0.4
+	// phraseBoost rewards documents whose phrase fields contain the whole query.
+	phraseBoost = 5
+)
+
+// schema describes how an index weighs its fields.
+type schema struct {
+	// fields lists the indexed fields in the order they are reported in matched_fields.
+	fields []Field
+	// weights ranks a hit in one field above a hit in another.
+	weights map[Field]float64
+	// phrase lists the fields checked for the full query.
+	phrase []Field
+	// splitCourseCodes splits query tokens like "cs3345" into prefix and number.
+	splitCourseCodes bool
+}
+
+type posting struct {
+	doc   int
+	field Field
+}
+
+// index is an inverted index from tokens to the document fields containing them.
+type index struct {
+	schema   schema
+	docs     []map[Field]string
+	postings map[string][]posting
+	tokens   []string
+}
+
+// match is a document that satisfied every query token.
+type match struct {
+	doc    int
+	score  float64
+	fields []Field
+}
+
+func newIndex(s schema, docs []map[Field]string) *index {
+	idx := &index{
+		schema:   s,
+		docs:     docs,
+		postings: make(map[string][]posting),
+	}
+
+	for doc, fields := range docs {
+		for _, field := range s.fields {
+			seen := make(map[string]struct{})
+			for _, token := range Tokenize(fields[field]) {
+				if _, ok := seen[token]; ok {
+					continue
+				}
+				seen[token] = struct{}{}
+				idx.postings[token] = append(idx.postings[token], posting{doc: doc, field: field})
+			}
+		}
+	}
+
+	idx.tokens = make([]string, 0, len(idx.postings))
+	for token := range idx.postings {
+		idx.tokens = append(idx.tokens, token)
+	}
+	sort.Strings(idx.tokens)
+
+	return idx
+}
+
+// Tokenize lowercases text and splits it on anything that is not a letter or digit.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// courseCodePattern matches a course prefix run together with its number, as
+// in "cs3345" or "cs4v98".
+var courseCodePattern = regexp.MustCompile(`^([a-z]{2,4})([0-9][0-9a-z]*)$`)
+
+// queryTokens tokenizes a query and, when the schema asks for it, splits
+// run-together course codes so "CS3345" searches the same as "cs 3345".
+func (i *index) queryTokens(query string) []string {
+	var tokens []string
+	for _, token := range Tokenize(query) {
+		if !i.schema.splitCourseCodes {
+			tokens = append(tokens, token)
+			continue
+		}
+		if parts := courseCodePattern.FindStringSubmatch(token); parts != nil {
+			tokens = append(tokens, parts[1], parts[2])
+			continue
+		}
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+/*
+search returns every document matching all query tokens, best match first.
+
+A query token matches an indexed token exactly, as a prefix (so "algo" and
+"33" find "algorithms" and "3345"), or within a few typos (so "algoritms"
+still finds "algorithms"). Each document's score sums, for every query token,
+the weight of the best field it hit, discounted for prefix and typo matches,
+plus a boost when a phrase field contains the whole query. Ties keep document
+order, so callers should pass documents in a stable order.
+*/
+func (i *index) search(query string) []match {
+	tokens := i.queryTokens(query)
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	scores := make(map[int]float64)
+	matched := make(map[int]map[Field]struct{})
+
+	for n, queryToken := range tokens {
+		tokenScores := make(map[int]float64)
+		for token, weight := range i.expand(queryToken) {
+			for _, p := range i.postings[token] {
+				if n > 0 {
+					if _, ok := scores[p.doc]; !ok {
+						continue
+					}
+				}
+				if score := i.schema.weights[p.field] * weight; score > tokenScores[p.doc] {
+					tokenScores[p.doc] = score
+				}
+				if matched[p.doc] == nil {
+					matched[p.doc] = make(map[Field]struct{})
+				}
+				matched[p.doc][p.field] = struct{}{}
+			}
+		}
+
+		// Every query token must match somewhere in the document.
+		next := make(map[int]float64, len(tokenScores))
+		for doc, score := range tokenScores {
+			next[doc] = scores[doc] + score
+		}
+		scores = next
+		if len(scores) == 0 {
+			return nil
+		}
+	}
+
+	phrase := joinTokens(tokens)
+	matches := make([]match, 0, len(scores))
+	for doc, score := range scores {
+		for _, field := range i.schema.phrase {
+			if strings.Contains(joinTokens(Tokenize(i.docs[doc][field])), phrase) {
+				score += phraseBoost
+				break
+			}
+		}
+
+		fields := make([]Field, 0, len(matched[doc]))
+		for _, field := range i.schema.fields {
+			if _, ok := matched[doc][field]; ok {
+				fields = append(fields, field)
+			}
+		}
+
+		matches = append(matches, match{doc: doc, score: score, fields: fields})
+	}
+
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return matches[a].doc < matches[b].doc
+	})
+
+	return matches
+}
+
+// joinTokens pads tokens with spaces so a phrase only matches on token boundaries.
+func joinTokens(tokens []string) string {
+	return " " + strings.Join(tokens, " ") + " "
+}
+
+// expand maps each indexed token a query token matches to the weight of that
+// match. Prefix matching needs at least two characters to be selective, and
+// tokens containing digits must match exactly or by prefix since a typo in a
+// course or class number names a different course.
+func (i *index) expand(queryToken string) map[string]float64 {
+	expanded := make(map[string]float64)
+	if _, ok := i.postings[queryToken]; ok {
+		expanded[queryToken] = 1
+	}
+
+	if len([]rune(queryToken)) >= 2 {
+		start := sort.SearchStrings(i.tokens, queryToken)
+		for _, token := range i.tokens[start:] {
+			if !strings.HasPrefix(token, queryToken) {
+				break
+			}
+			if token != queryToken {
+				expanded[token] = prefixMatchWeight
+			}
+		}
+	}
+
+	limit := maxEdits(queryToken)
+	if limit == 0 || strings.IndexFunc(queryToken, unicode.IsDigit) >= 0 {
+		return expanded
+	}
+	for _, token := range i.tokens {
+		if _, ok := expanded[token]; ok {
+			continue
+		}
+		if distance := editDistance(queryToken, token, limit); distance <= limit {
+			expanded[token] = fuzzyMatchWeight / float64(distance)
+		}
+	}
+
+	return expanded
+}
@@ -0,0 +1,51 @@
+package search
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Data Structures", []string{"data", "structures"}},
+		{"CS 3345.001", []string{"cs", "3345", "001"}},
+		{"Doe, Jane; Smith-Jones, Al", []string{"doe", "jane", "smith", "jones", "al"}},
+		{"  ", nil},
+		{"Café Über", []string{"café", "über"}},
+	}
+	for _, tt := range tests {
+		if got := Tokenize(tt.text); !slices.Equal(got, tt.want) {
+			t.Errorf("Tokenize(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestQueryTokensSplitsCourseCodes(t *testing.T) {
+	courses := newIndex(courseSchema, nil)
+	professors := newIndex(professorSchema, nil)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"CS3345", []string{"cs", "3345"}},
+		{"cs 3345", []string{"cs", "3345"}},
+		{"cs4v98", []string{"cs", "4v98"}},
+		{"ecsc3345 algorithms", []string{"ecsc", "3345", "algorithms"}},
+		// Prefixes are two to four letters, so longer words are left alone.
+		{"algorithms2", []string{"algorithms2"}},
+		{"3345", []string{"3345"}},
+	}
+	for _, tt := range tests {
+		if got := courses.queryTokens(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("course queryTokens(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	if got := professors.queryTokens("jxd123456"); !slices.Equal(got, []string{"jxd123456"}) {
+		t.Errorf("professor queryTokens split an instructor ID: %q", got)
+	}
+}
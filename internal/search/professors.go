@@ -0,0 +1,175 @@
+package search
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// professorSchema ranks an instructor ID hit first, then names, then departments.
+var professorSchema = schema{
+	fields: []Field{FieldInstructorID, FieldName, FieldDepartment},
+	weights: map[Field]float64{
+		FieldInstructorID: 10,
+		FieldName:         5,
+		FieldDepartment:   1,
+	},
+	phrase: []Field{FieldName},
+}
+
+// ProfessorResult is a matched professor along with its relevance score and
+// the fields the query matched.
+type ProfessorResult struct {
+	types.Professor
+	Score         float64 `json:"score"`
+	MatchedFields []Field `json:"matched_fields"`
+}
+
+// ProfessorIndex is an inverted index over the professors collection.
+type ProfessorIndex struct {
+	professors []types.Professor
+	index      *index
+}
+
+// NewProfessorIndex tokenizes every professor's instructor ID, coursebook and
+// RateMyProfessors names, and department.
+func NewProfessorIndex(professors []types.Professor) *ProfessorIndex {
+	sorted := make([]types.Professor, len(professors))
+	copy(sorted, professors)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].InstructorID < sorted[b].InstructorID
+	})
+
+	docs := make([]map[Field]string, len(sorted))
+	for i, professor := range sorted {
+		docs[i] = map[Field]string{
+			FieldInstructorID: professor.InstructorID,
+			FieldName:         professor.NormalizedCoursebookName + " " + professor.OriginalRMPFormat,
+			FieldDepartment:   professor.Department,
+		}
+	}
+
+	return &ProfessorIndex{professors: sorted, index: newIndex(professorSchema, docs)}
+}
+
+// Len reports how many professors the index holds.
+func (i *ProfessorIndex) Len() int {
+	return len(i.professors)
+}
+
+// Search returns the professors matching every query token, best match first.
+// Ties are ordered by instructor ID.
+func (i *ProfessorIndex) Search(query string) []ProfessorResult {
+	matches := i.index.search(query)
+	results := make([]ProfessorResult, len(matches))
+	for n, m := range matches {
+		results[n] = ProfessorResult{Professor: i.professors[m.doc], Score: m.score, MatchedFields: m.fields}
+	}
+	return results
+}
+
+// Filter returns the professors matching the filter, ordered by instructor ID.
+func (i *ProfessorIndex) Filter(filter ProfessorFilter) []types.Professor {
+	professors := []types.Professor{}
+	for _, professor := range i.professors {
+		if filter.Matches(professor) {
+			professors = append(professors, professor)
+		}
+	}
+	return professors
+}
+
+// ProfessorFilter narrows the professor directory. Zero values place no limit.
+type ProfessorFilter struct {
+	Department     string
+	MinQuality     float64
+	MaxDifficulty  float64
+	MinRatings     int
+	Tags           []string // Every tag must be present
+	MinGradeRating float64
+	MaxGradeRating float64
+}
+
+// Matches reports whether the professor satisfies every field of the filter.
+// Professors without RateMyProfessors ratings never meet a rating bound, and
+// professors without grade data never meet a grade rating bound.
+func (f ProfessorFilter) Matches(professor types.Professor) bool {
+	rated := professor.RatingsCount > 0
+	graded := professor.TotalGradeCount > 0
+
+	switch {
+	case !equalFoldIfSet(f.Department, professor.Department),
+		f.MinQuality > 0 && (!rated || professor.QualityRating < f.MinQuality),
+		f.MaxDifficulty > 0 && (!rated || professor.DifficultyRating > f.MaxDifficulty),
+		f.MinRatings > 0 && professor.RatingsCount < f.MinRatings,
+		f.MinGradeRating > 0 && (!graded || professor.OverallGradeRating < f.MinGradeRating),
+		f.MaxGradeRating > 0 && (!graded || professor.OverallGradeRating > f.MaxGradeRating):
+		return false
+	}
+
+	for _, tag := range f.Tags {
+		if !slices.ContainsFunc(professor.Tags, func(t string) bool {
+			return strings.EqualFold(strings.TrimSpace(t), tag)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// TagCount is how many professors carry a RateMyProfessors tag.
+type TagCount struct {
+	Tag   string  `json:"tag"`
+	Count int     `json:"count"`
+	Share float64 `json:"share"` // Fraction (0-1) of the tagged professors
+}
+
+// TagCounts tallies the tags of the professors matching the filter, most
+// common first, and reports how many matched and how many of those carry any
+// tag. Tags are compared case-insensitively and reported with the spelling of
+// the first professor carrying them.
+func (i *ProfessorIndex) TagCounts(filter ProfessorFilter) ([]TagCount, int, int) {
+	var counts []TagCount
+	index := make(map[string]int)
+	matched, tagged := 0, 0
+	for _, professor := range i.Filter(filter) {
+		matched++
+		seen := make(map[string]bool)
+		for _, tag := range professor.Tags {
+			tag = strings.TrimSpace(tag)
+			key := strings.ToLower(tag)
+			if tag == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			n, ok := index[key]
+			if !ok {
+				n = len(counts)
+				index[key] = n
+				counts = append(counts, TagCount{Tag: tag})
+			}
+			counts[n].Count++
+		}
+		if len(seen) > 0 {
+			tagged++
+		}
+	}
+
+	for n := range counts {
+		counts[n].Share = math.Round(float64(counts[n].Count)/float64(tagged)*10000) / 10000
+	}
+	sort.SliceStable(counts, func(a, b int) bool {
+		if counts[a].Count != counts[b].Count {
+			return counts[a].Count > counts[b].Count
+		}
+		return strings.ToLower(counts[a].Tag) < strings.ToLower(counts[b].Tag)
+	})
+	if counts == nil {
+		counts = []TagCount{}
+	}
+	return counts, matched, tagged
+}
@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+	"github.com/patrickmn/go-cache"
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrInvalidTerm is returned for course searches in a term that is not a valid
+// term code, which would otherwise each cache an empty index.
+var ErrInvalidTerm = errors.New("search: invalid term")
+
+// Source is the storage the search indexes are built from.
+type Source interface {
+	storage.CourseStore
+	storage.ProfessorStore
+}
+
+// professorIndexKey is the cache key of the professor index; course indexes
+// are keyed by "courses:{term}".
+const professorIndexKey = "professors"
+
+// Service answers searches from indexes held in memory. Each term's course
+// index, and the professor index, is built from storage the first time it is
+// searched and rebuilt once it expires, so newly ingested data shows up within
+// one TTL. Concurrent requests for an index that is not cached share a single
+// build.
+type Service struct {
+	source  Source
+	indexes *cache.Cache
+	builds  singleflight.Group
+}
+
+func NewService(source Source, ttl time.Duration) *Service {
+	return &Service{
+		source:  source,
+		indexes: cache.New(ttl, 2*ttl),
+	}
+}
+
+// CourseIndex returns the search index for a term, building it if needed. It
+// returns ErrInvalidTerm when term is not a term code such as "24f".
+func (s *Service) CourseIndex(ctx context.Context, term string) (*CourseIndex, error) {
+	parsed, err := types.ParseTerm(term)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTerm, err)
+	}
+
+	index, err := cachedIndex(ctx, s, "courses:"+parsed.Code, func(ctx context.Context) (*CourseIndex, error) {
+		start := time.Now()
+		courses, _, err := s.source.GetAllCoursesByTerm(ctx, parsed.Code, storage.Page{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load courses for search index: %w", err)
+		}
+
+		index := NewCourseIndex(courses)
+		log.Printf("Built course search index for term %s: %d sections in %s", parsed.Code, index.Len(), time.Since(start).Round(time.Millisecond))
+		return index, nil
+	})
+	return index, err
+}
+
+// ProfessorIndex returns the professor search index, building it if needed.
+func (s *Service) ProfessorIndex(ctx context.Context) (*ProfessorIndex, error) {
+	return cachedIndex(ctx, s, professorIndexKey, func(ctx context.Context) (*ProfessorIndex, error) {
+		start := time.Now()
+		professors, err := s.source.ListProfessors(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load professors for search index: %w", err)
+		}
+
+		index := NewProfessorIndex(professors)
+		log.Printf("Built professor search index: %d professors in %s", index.Len(), time.Since(start).Round(time.Millisecond))
+		return index, nil
+	})
+}
+
+// cachedIndex returns the index cached under key, or builds and caches it.
+// Callers that miss the cache while a build for key is running wait for that
+// build instead of starting their own. The build ignores the cancellation of
+// the request that started it, since other requests may be waiting on it.
+func cachedIndex[T any](ctx context.Context, s *Service, key string, build func(context.Context) (*T, error)) (*T, error) {
+	if cached, ok := s.indexes.Get(key); ok {
+		return cached.(*T), nil
+	}
+
+	value, err, _ := s.builds.Do(key, func() (any, error) {
+		// A build that finished between the lookup above and this call has
+		// already cached the index.
+		if cached, ok := s.indexes.Get(key); ok {
+			return cached, nil
+		}
+		index, err := build(context.WithoutCancel(ctx))
+		if err != nil {
+			return nil, err
+		}
+		s.indexes.Set(key, index, cache.DefaultExpiration)
+		return index, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*T), nil
+}
+
+// SearchCourses ranks the term's sections matching the filter against the query.
+func (s *Service) SearchCourses(ctx context.Context, term, query string, filter CourseFilter) ([]CourseResult, error) {
+	index, err := s.CourseIndex(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return FilterResults(index.Search(query), filter), nil
+}
+
+// FilterCourses returns the term's sections matching the filter, ordered by
+// section address. It serves filters the storage backends cannot query directly.
+func (s *Service) FilterCourses(ctx context.Context, term string, filter CourseFilter) ([]types.Course, error) {
+	index, err := s.CourseIndex(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return index.Filter(filter), nil
+}
+
+// Schools summarizes the schools offering sections in the term.
+func (s *Service) Schools(ctx context.Context, term string) ([]types.SchoolSummary, error) {
+	index, err := s.CourseIndex(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return index.Schools(), nil
+}
+
+// SearchProfessors ranks professors against the query.
+func (s *Service) SearchProfessors(ctx context.Context, query string) ([]ProfessorResult, error) {
+	index, err := s.ProfessorIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return index.Search(query), nil
+}
+
+// FilterProfessors returns the professors matching the filter, ordered by
+// instructor ID.
+func (s *Service) FilterProfessors(ctx context.Context, filter ProfessorFilter) ([]types.Professor, error) {
+	index, err := s.ProfessorIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return index.Filter(filter), nil
+}
+
+// ProfessorTagCounts tallies the tags of the professors matching the filter.
+// See ProfessorIndex.TagCounts.
+func (s *Service) ProfessorTagCounts(ctx context.Context, filter ProfessorFilter) ([]TagCount, int, int, error) {
+	index, err := s.ProfessorIndex(ctx)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	counts, matched, tagged := index.TagCounts(filter)
+	return counts, matched, tagged, nil
+}
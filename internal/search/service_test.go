@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// countingSource counts term loads and holds each one until release is closed.
+type countingSource struct {
+	*memory.Store
+	loads   atomic.Int32
+	release chan struct{}
+}
+
+func (s *countingSource) GetAllCoursesByTerm(ctx context.Context, term string, page storage.Page) ([]types.Course, string, error) {
+	s.loads.Add(1)
+	<-s.release
+	return s.Store.GetAllCoursesByTerm(ctx, term, page)
+}
+
+func newCountingSource() *countingSource {
+	return &countingSource{
+		Store: memory.NewFromFixtures(&storage.Fixtures{Courses: []types.Course{
+			{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f"},
+		}}),
+		release: make(chan struct{}),
+	}
+}
+
+func TestCourseIndexRejectsInvalidTerms(t *testing.T) {
+	source := newCountingSource()
+	close(source.release)
+	service := NewService(source, time.Minute)
+
+	for _, term := range []string{"", "fall", "24x", "24f/../"} {
+		if _, err := service.CourseIndex(context.Background(), term); !errors.Is(err, ErrInvalidTerm) {
+			t.Errorf("CourseIndex(%q) error = %v, want ErrInvalidTerm", term, err)
+		}
+	}
+	if loads := source.loads.Load(); loads != 0 {
+		t.Errorf("invalid terms loaded storage %d times, want 0", loads)
+	}
+
+	// Spellings of the same term share one cached index.
+	for _, term := range []string{"24f", "24F", " 24f "} {
+		index, err := service.CourseIndex(context.Background(), term)
+		if err != nil || index.Len() != 1 {
+			t.Fatalf("CourseIndex(%q) = %v, %v; want the 24f index", term, index, err)
+		}
+	}
+	if loads := source.loads.Load(); loads != 1 {
+		t.Errorf("storage loaded %d times, want 1", loads)
+	}
+}
+
+func TestCourseIndexSharesConcurrentBuilds(t *testing.T) {
+	source := newCountingSource()
+	service := NewService(source, time.Minute)
+
+	const callers = 8
+	var wg sync.WaitGroup
+	indexes := make([]*CourseIndex, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			index, err := service.CourseIndex(context.Background(), "24f")
+			if err != nil {
+				t.Errorf("CourseIndex: %v", err)
+			}
+			indexes[i] = index
+		}()
+	}
+
+	// Let the first build start, then give the other callers time to join it.
+	for source.loads.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(source.release)
+	wg.Wait()
+
+	if loads := source.loads.Load(); loads != 1 {
+		t.Errorf("%d concurrent callers loaded storage %d times, want 1", callers, loads)
+	}
+	for i, index := range indexes {
+		if index != indexes[0] {
+			t.Errorf("caller %d got a different index", i)
+		}
+	}
+}
@@ -1,34 +1,55 @@
 package handlers
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
-	"github.com/acmutd/acmutd-api/internal/firebase"
+	"github.com/acmutd/acmutd-api/internal/gradestats"
+	"github.com/acmutd/acmutd-api/internal/schedule"
+	"github.com/acmutd/acmutd-api/internal/search"
+	"github.com/acmutd/acmutd-api/internal/storage"
 	"github.com/acmutd/acmutd-api/internal/types"
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	db *firebase.Firestore
+	db     storage.Store
+	search *search.Service
 }
 
 const (
 	defaultLimit = 100
 	maxLimit     = 100
+
+	// maxSectionsPerRequest caps how many section addresses a request body can list.
+	maxSectionsPerRequest = 50
+
+	defaultScheduleLimit = 10
+	maxScheduleLimit     = 50
 )
 
 type paginationParams struct {
 	Limit  int
 	Page   int
 	Offset int
+	Cursor string
+}
+
+// storagePage converts request pagination into a storage.Page.
+func (p paginationParams) storagePage() storage.Page {
+	return storage.Page{Limit: p.Limit, Offset: p.Offset, Cursor: p.Cursor}
 }
 
-func New(db *firebase.Firestore) *Handler {
-	return &Handler{db: db}
+func New(db storage.Store, searchService *search.Service) *Handler {
+	return &Handler{db: db, search: searchService}
 }
 
 // Health responds with a simple service heartbeat.
@@ -46,8 +67,30 @@ func (h *Handler) GetAllCourses(c *gin.Context) {
 	})
 }
 
+// coursePresenter shapes a page of courses for a particular API version.
+type coursePresenter func([]types.Course) any
+
+// presentCoursesV1 returns courses exactly as stored.
+func presentCoursesV1(courses []types.Course) any {
+	return courses
+}
+
+// presentCoursesV2 returns courses with typed enrollment, meeting, and list fields.
+func presentCoursesV2(courses []types.Course) any {
+	return types.NewCoursesV2(courses)
+}
+
 // GetCoursesByTerm fetches courses and applies optional prefix/number filters.
 func (h *Handler) GetCoursesByTerm(c *gin.Context) {
+	h.coursesByTerm(c, presentCoursesV1)
+}
+
+// GetCoursesByTermV2 is GetCoursesByTerm with v2 course objects.
+func (h *Handler) GetCoursesByTermV2(c *gin.Context) {
+	h.coursesByTerm(c, presentCoursesV2)
+}
+
+func (h *Handler) coursesByTerm(c *gin.Context, present coursePresenter) {
 	term := normalizeTerm(c.Param("term"))
 	if term == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
@@ -59,41 +102,61 @@ func (h *Handler) GetCoursesByTerm(c *gin.Context) {
 		return
 	}
 
-	prefix := normalizePrefix(c.Query("prefix"))
-	number := normalizeCourseNumber(c.Query("number"))
+	filter, ok := parseCourseFilterOrRespond(c)
+	if !ok {
+		return
+	}
 
 	var (
-		courses []types.Course
-		hasNext bool
-		err     error
+		courses    []types.Course
+		nextCursor string
+		err        error
 	)
 
 	switch {
-	case prefix != "" && number != "":
-		courses, hasNext, err = h.db.QueryByCourseNumber(c.Request.Context(), term, prefix, number, params.Limit, params.Offset)
-	case prefix != "":
-		courses, hasNext, err = h.db.QueryByCoursePrefix(c.Request.Context(), term, prefix, params.Limit, params.Offset)
+	case !storageServes(filter):
+		courses, nextCursor, err = h.filteredCourses(c.Request.Context(), term, filter, params.storagePage())
+	case filter.InstructorID != "":
+		courses, nextCursor, err = h.db.QueryByInstructor(c.Request.Context(), term, filter.InstructorID, params.storagePage())
+	case filter.Prefix != "" && filter.Number != "":
+		courses, nextCursor, err = h.db.QueryByCourseNumber(c.Request.Context(), term, filter.Prefix, filter.Number, params.storagePage())
+	case filter.Prefix != "":
+		courses, nextCursor, err = h.db.QueryByCoursePrefix(c.Request.Context(), term, filter.Prefix, params.storagePage())
+	case filter.School != "":
+		courses, nextCursor, err = h.db.QueryBySchool(c.Request.Context(), term, filter.School, params.storagePage())
 	default:
-		courses, hasNext, err = h.db.GetAllCoursesByTerm(c.Request.Context(), term, params.Limit, params.Offset)
+		courses, nextCursor, err = h.db.GetAllCoursesByTerm(c.Request.Context(), term, params.storagePage())
 	}
 
 	if err != nil {
+		if respondInvalidCursor(c, err) || respondInvalidTerm(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(courses), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(courses), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"term":       term,
 		"count":      len(courses),
-		"courses":    courses,
+		"courses":    present(courses),
 		"pagination": pagination,
 	})
 }
 
 // GetCoursesByPrefix fetches courses by prefix within a term.
 func (h *Handler) GetCoursesByPrefix(c *gin.Context) {
+	h.coursesByPrefix(c, presentCoursesV1)
+}
+
+// GetCoursesByPrefixV2 is GetCoursesByPrefix with v2 course objects.
+func (h *Handler) GetCoursesByPrefixV2(c *gin.Context) {
+	h.coursesByPrefix(c, presentCoursesV2)
+}
+
+func (h *Handler) coursesByPrefix(c *gin.Context, present coursePresenter) {
 	term := normalizeTerm(c.Param("term"))
 	prefix := normalizePrefix(c.Param("prefix"))
 
@@ -107,25 +170,52 @@ func (h *Handler) GetCoursesByPrefix(c *gin.Context) {
 		return
 	}
 
-	courses, hasNext, err := h.db.QueryByCoursePrefix(c.Request.Context(), term, prefix, params.Limit, params.Offset)
+	filter, ok := parseCourseFilterOrRespond(c)
+	if !ok {
+		return
+	}
+	filter.Prefix, filter.Number = prefix, ""
+
+	var (
+		courses    []types.Course
+		nextCursor string
+		err        error
+	)
+	if storageServes(filter) {
+		courses, nextCursor, err = h.db.QueryByCoursePrefix(c.Request.Context(), term, prefix, params.storagePage())
+	} else {
+		courses, nextCursor, err = h.filteredCourses(c.Request.Context(), term, filter, params.storagePage())
+	}
 	if err != nil {
+		if respondInvalidCursor(c, err) || respondInvalidTerm(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(courses), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(courses), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"term":       term,
 		"prefix":     prefix,
 		"count":      len(courses),
-		"courses":    courses,
+		"courses":    present(courses),
 		"pagination": pagination,
 	})
 }
 
 // GetCoursesByNumber fetches courses by prefix and number in a term.
 func (h *Handler) GetCoursesByNumber(c *gin.Context) {
+	h.coursesByNumber(c, presentCoursesV1)
+}
+
+// GetCoursesByNumberV2 is GetCoursesByNumber with v2 course objects.
+func (h *Handler) GetCoursesByNumberV2(c *gin.Context) {
+	h.coursesByNumber(c, presentCoursesV2)
+}
+
+func (h *Handler) coursesByNumber(c *gin.Context, present coursePresenter) {
 	term := normalizeTerm(c.Param("term"))
 	prefix := normalizePrefix(c.Param("prefix"))
 	number := normalizeCourseNumber(c.Param("number"))
@@ -140,25 +230,43 @@ func (h *Handler) GetCoursesByNumber(c *gin.Context) {
 		return
 	}
 
-	courses, hasNext, err := h.db.QueryByCourseNumber(c.Request.Context(), term, prefix, number, params.Limit, params.Offset)
+	filter, ok := parseCourseFilterOrRespond(c)
+	if !ok {
+		return
+	}
+	filter.Prefix, filter.Number = prefix, number
+
+	var (
+		courses    []types.Course
+		nextCursor string
+		err        error
+	)
+	if storageServes(filter) {
+		courses, nextCursor, err = h.db.QueryByCourseNumber(c.Request.Context(), term, prefix, number, params.storagePage())
+	} else {
+		courses, nextCursor, err = h.filteredCourses(c.Request.Context(), term, filter, params.storagePage())
+	}
 	if err != nil {
+		if respondInvalidCursor(c, err) || respondInvalidTerm(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(courses), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(courses), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"term":       term,
 		"prefix":     prefix,
 		"number":     number,
 		"count":      len(courses),
-		"courses":    courses,
+		"courses":    present(courses),
 		"pagination": pagination,
 	})
 }
 
-// SearchCourses runs a text search against courses for a term.
+// SearchCourses ranks a term's courses against the query using the in-memory search index.
 func (h *Handler) SearchCourses(c *gin.Context) {
 	term := normalizeTerm(c.Param("term"))
 	query := strings.TrimSpace(c.Query("q"))
@@ -178,129 +286,1461 @@ func (h *Handler) SearchCourses(c *gin.Context) {
 		return
 	}
 
-	courses, hasNext, err := h.db.SearchCourses(c.Request.Context(), term, query, params.Limit, params.Offset)
+	if params.Cursor != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor parameter is not supported for search; use page instead"})
+		return
+	}
+
+	filter, ok := parseCourseFilterOrRespond(c)
+	if !ok {
+		return
+	}
+
+	results, err := h.search.SearchCourses(c.Request.Context(), term, query, filter)
 	if err != nil {
+		if respondInvalidTerm(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	courses, hasNext := paginateResults(results, params)
 	pagination := buildPaginationMeta(params, len(courses), hasNext)
 
 	c.JSON(http.StatusOK, gin.H{
 		"term":       term,
 		"query":      query,
 		"count":      len(courses),
+		"total":      len(results),
 		"courses":    courses,
 		"pagination": pagination,
 	})
 }
 
-// GetTerms returns all known terms.
-func (h *Handler) GetTerms(c *gin.Context) {
-	params, ok := parsePaginationOrRespond(c)
+// CheckConflicts loads a set of sections in a term and reports every pair
+// whose meetings overlap.
+func (h *Handler) CheckConflicts(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	var req struct {
+		Sections []string `json:"sections" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addresses := normalizeSectionAddresses(req.Sections)
+	if len(addresses) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sections must list at least one section address"})
+		return
+	}
+	if len(addresses) > maxSectionsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("sections cannot list more than %d section addresses", maxSectionsPerRequest)})
+		return
+	}
+
+	courses, notFound, err := h.sectionsInTerm(c.Request.Context(), term, addresses)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	conflicts := schedule.FindConflicts(courses)
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":          term,
+		"sections":      sectionAddresses(courses),
+		"has_conflicts": len(conflicts) > 0,
+		"conflicts":     conflicts,
+		"not_found":     notFound,
+		"unscheduled":   schedule.Unscheduled(courses),
+	})
+}
+
+// GenerateSchedules builds ranked, conflict-free combinations of sections
+// covering every requested course in a term.
+func (h *Handler) GenerateSchedules(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	var req struct {
+		Courses []struct {
+			Prefix string `json:"prefix"`
+			Number string `json:"number"`
+		} `json:"courses" binding:"required"`
+		Constraints struct {
+			EarliestStart string   `json:"earliest_start"`
+			LatestEnd     string   `json:"latest_end"`
+			DaysOff       []string `json:"days_off"`
+			OpenOnly      bool     `json:"open_only"`
+			MinRating     float64  `json:"min_rating"`
+		} `json:"constraints"`
+		Limit int `json:"limit"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	type courseKey struct{ prefix, number string }
+	var requested []courseKey
+	seen := make(map[courseKey]bool)
+	for _, course := range req.Courses {
+		key := courseKey{normalizePrefix(course.Prefix), normalizeCourseNumber(course.Number)}
+		if key.prefix == "" || key.number == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "each course requires a prefix and number"})
+			return
+		}
+		if !seen[key] {
+			seen[key] = true
+			requested = append(requested, key)
+		}
+	}
+	if len(requested) == 0 || len(requested) > schedule.MaxCourses {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("courses must list between 1 and %d courses", schedule.MaxCourses)})
+		return
+	}
+
+	constraints := schedule.Constraints{OpenOnly: req.Constraints.OpenOnly, MinRating: req.Constraints.MinRating}
+	var err error
+	if constraints.EarliestStart, err = parseTimeBound("earliest_start", req.Constraints.EarliestStart); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if constraints.LatestEnd, err = parseTimeBound("latest_end", req.Constraints.LatestEnd); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if constraints.EarliestStart != nil && constraints.LatestEnd != nil && *constraints.EarliestStart >= *constraints.LatestEnd {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "earliest_start must be earlier than latest_end"})
+		return
+	}
+	for _, day := range req.Constraints.DaysOff {
+		parsed := types.ParseDays(day)
+		if len(parsed) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("days_off entry %q is not a weekday", day)})
+			return
+		}
+		constraints.DaysOff = append(constraints.DaysOff, parsed...)
+	}
+	if constraints.MinRating < 0 || constraints.MinRating > 5 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_rating must be between 0 and 5"})
+		return
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultScheduleLimit
+	}
+	limit = min(limit, maxScheduleLimit)
+
+	ctx := c.Request.Context()
+	notFound := []gin.H{}
+	sectionsByCourse := make([][]types.Course, len(requested))
+	for i, course := range requested {
+		sections, _, err := h.db.QueryByCourseNumber(ctx, term, course.prefix, course.number, storage.Page{})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if len(sections) == 0 {
+			notFound = append(notFound, gin.H{"course_prefix": course.prefix, "course_number": course.number})
+		}
+		sectionsByCourse[i] = sections
+	}
+
+	professors, err := h.professorsTeaching(ctx, slices.Concat(sectionsByCourse...))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professors"})
+		return
+	}
+
+	var components []schedule.Component
+	unsatisfiable := []schedule.Component{}
+	notes := []string{}
+	for i, course := range requested {
+		var candidates []schedule.Candidate
+		for _, section := range sectionsByCourse[i] {
+			var instructors []types.Professor
+			for _, id := range storage.InstructorIDList(section.InstructorIDs) {
+				if professor, ok := professors[id]; ok {
+					instructors = append(instructors, professor)
+				}
+			}
+			candidates = append(candidates, schedule.Candidate{Course: section, Rating: schedule.RateSection(instructors)})
+		}
+
+		courseComponents := schedule.Components(course.prefix, course.number, candidates)
+		if len(courseComponents) > 1 {
+			notes = append(notes, unpairedComponentsNote(courseComponents))
+		}
+		for _, component := range courseComponents {
+			allowed := component.Candidates[:0:0]
+			for _, candidate := range component.Candidates {
+				if constraints.Allows(candidate.Course, candidate.Rating) {
+					allowed = append(allowed, candidate)
+				}
+			}
+			component.Candidates = allowed
+			if len(allowed) == 0 {
+				unsatisfiable = append(unsatisfiable, component)
+			}
+			components = append(components, component)
+		}
+	}
+
+	schedules, truncated := []schedule.Schedule{}, false
+	if len(notFound) == 0 && len(unsatisfiable) == 0 {
+		schedules, truncated = schedule.Generate(components, limit)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":          term,
+		"count":         len(schedules),
+		"schedules":     schedules,
+		"truncated":     truncated,
+		"not_found":     notFound,
+		"unsatisfiable": unsatisfiable,
+		"notes":         notes,
+	})
+}
+
+// unpairedComponentsNote warns that a course's components, such as a lecture
+// and its labs, are picked independently: coursebook does not record which lab
+// sections belong to which lecture, so a schedule may pair sections that
+// registration does not allow together.
+func unpairedComponentsNote(components []schedule.Component) string {
+	activities := make([]string, len(components))
+	for i, component := range components {
+		activities[i] = component.ActivityType
+		if activities[i] == "" {
+			activities[i] = "unlabeled"
+		}
+	}
+	return fmt.Sprintf("%s %s: %s sections are picked independently because coursebook does not link them; check that each pairing is allowed at registration",
+		strings.ToUpper(components[0].CoursePrefix), strings.ToUpper(components[0].CourseNumber), strings.Join(activities, ", "))
+}
+
+// professorsTeaching loads the professors listed on the sections, keyed by
+// normalized instructor ID. Instructors without a stored profile are left out.
+func (h *Handler) professorsTeaching(ctx context.Context, sections []types.Course) (map[string]types.Professor, error) {
+	professors := make(map[string]types.Professor)
+	looked := make(map[string]bool)
+	for _, section := range sections {
+		for _, id := range storage.InstructorIDList(section.InstructorIDs) {
+			if looked[id] {
+				continue
+			}
+			looked[id] = true
+
+			professor, err := h.db.GetProfessorById(ctx, id)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			professors[id] = *professor
+		}
+	}
+	return professors, nil
+}
+
+// ExportCalendar renders the requested sections as an iCalendar file of weekly
+// recurring events spanning the term.
+func (h *Handler) ExportCalendar(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	parsedTerm, err := types.ParseTerm(term)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var values []string
+	for _, value := range c.QueryArray("sections") {
+		values = append(values, strings.Split(value, ",")...)
+	}
+	addresses := normalizeSectionAddresses(values)
+	if len(addresses) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sections parameter must list at least one section address"})
+		return
+	}
+	if len(addresses) > maxSectionsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("sections cannot list more than %d section addresses", maxSectionsPerRequest)})
+		return
+	}
+
+	courses, notFound, err := h.sectionsInTerm(c.Request.Context(), term, addresses)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(notFound) > 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "sections not found", "not_found": notFound})
+		return
+	}
+
+	calendar, err := schedule.Calendar(parsedTerm, courses, time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-schedule.ics"`, term))
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(calendar))
+}
+
+// professorComparison describes how one instructor has taught a course,
+// joining their combined grade records with their RateMyProfessors profile.
+type professorComparison struct {
+	InstructorID    string           `json:"instructor_id"`
+	Name            string           `json:"name"`
+	HasProfile      bool             `json:"has_profile"` // Whether the instructor has a stored professor profile
+	QualityRating   float64          `json:"quality_rating"`
+	Difficulty      float64          `json:"difficulty_rating"`
+	WouldTakeAgain  int              `json:"would_take_again"`
+	RatingsCount    int              `json:"ratings_count"`
+	GradeRating     float64          `json:"overall_grade_rating"`
+	CourseRating    *float64         `json:"course_rating"` // The profile's rating for this course, if any
+	Sections        int              `json:"sections"`      // Grade records for the course
+	Terms           []string         `json:"terms"`
+	GradeStats      types.GradeStats `json:"stats"`
+	rated, hasGrade bool
+}
+
+// setGrades records the instructor's combined grade records for the course,
+// naming the instructor from them when no name is set yet.
+func (p *professorComparison) setGrades(summary gradestats.Summary) {
+	if p.Name == "" {
+		p.Name = displayName(summary.InstructorName)
+	}
+	p.Sections = summary.Sections
+	p.Terms = summary.Terms
+	p.GradeStats = summary.Stats
+	p.hasGrade = summary.Stats.GradedStudents > 0
+}
+
+// setProfile records the professor's ratings, taking the course rating for
+// course, a prefix and number such as "cs3345". The profile names the
+// instructor when no name is set yet.
+func (p *professorComparison) setProfile(professor types.Professor, course string) {
+	if p.Name == "" {
+		p.Name = displayName(professor.NormalizedCoursebookName)
+	}
+	p.HasProfile = true
+	p.QualityRating = professor.QualityRating
+	p.Difficulty = professor.DifficultyRating
+	p.WouldTakeAgain = professor.WouldTakeAgain
+	p.RatingsCount = professor.RatingsCount
+	p.GradeRating = professor.OverallGradeRating
+	p.rated = professor.RatingsCount > 0
+	for key, rating := range professor.CourseRatings {
+		if strings.EqualFold(key, course) {
+			p.CourseRating = &rating
+		}
+	}
+}
+
+// displayName formats an instructor name from any of its sources as "First
+// Last": grade records write "Last, First" and professor profiles store the
+// name in lowercase.
+func displayName(name string) string {
+	if last, first, ok := strings.Cut(name, ","); ok {
+		name = first + " " + last
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	if name != strings.ToLower(name) {
+		return name
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if i == 0 || !unicode.IsLetter(runes[i-1]) {
+			runes[i] = unicode.ToUpper(r)
+		}
+	}
+	return string(runes)
+}
+
+// comparisonSorts maps each sort parameter value to the metric it reads and
+// whether larger values sort first by default. Metrics an instructor has no
+// data for always sort last.
+var comparisonSorts = map[string]struct {
+	metric     func(professorComparison) (float64, bool)
+	descending bool
+}{
+	"gpa":              {func(p professorComparison) (float64, bool) { return p.GradeStats.MeanGPA, p.hasGrade }, true},
+	"quality":          {func(p professorComparison) (float64, bool) { return p.QualityRating, p.rated }, true},
+	"difficulty":       {func(p professorComparison) (float64, bool) { return p.Difficulty, p.rated }, false},
+	"would_take_again": {func(p professorComparison) (float64, bool) { return float64(p.WouldTakeAgain), p.rated }, true},
+	"course_rating": {func(p professorComparison) (float64, bool) {
+		if p.CourseRating == nil {
+			return 0, false
+		}
+		return *p.CourseRating, true
+	}, true},
+	"sections": {func(p professorComparison) (float64, bool) { return float64(p.Sections), true }, true},
+}
+
+// GetCourseProfessors compares the instructors who have taught a course, using
+// its grade history and their professor profiles.
+func (h *Handler) GetCourseProfessors(c *gin.Context) {
+	// The route is /courses/:prefix/:number/professors, but gin requires its
+	// first segment to share the :term wildcard of the other course routes.
+	prefix := normalizePrefix(c.Param("term"))
+	number := normalizeCourseNumber(c.Param("number"))
+
+	if prefix == "" || number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Prefix and number are required"})
+		return
+	}
+
+	sortBy := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort", "gpa")))
+	sortSpec, ok := comparisonSorts[sortBy]
 	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of gpa, quality, difficulty, would_take_again, course_rating, or sections"})
+		return
+	}
+	descending := sortSpec.descending
+	switch strings.ToLower(strings.TrimSpace(c.Query("order"))) {
+	case "":
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	grades, err := h.courseGrades(ctx, prefix, number, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no grades found for this course"})
+		return
+	}
+
+	comparisons := []professorComparison{}
+	for _, summary := range gradestats.ByInstructor(grades) {
+		comparison := professorComparison{InstructorID: summary.InstructorID}
+		comparison.setGrades(summary)
+
+		if summary.InstructorID != "" {
+			professor, err := h.db.GetProfessorById(ctx, summary.InstructorID)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professors"})
+				return
+			}
+			if err == nil {
+				comparison.setProfile(*professor, prefix+number)
+			}
+		}
+		comparisons = append(comparisons, comparison)
+	}
+
+	// ByInstructor orders by students taught, which breaks ties between equal metrics.
+	slices.SortStableFunc(comparisons, func(a, b professorComparison) int {
+		valueA, okA := sortSpec.metric(a)
+		valueB, okB := sortSpec.metric(b)
+		switch {
+		case okA != okB:
+			if okA {
+				return -1
+			}
+			return 1
+		case descending:
+			return cmp.Compare(valueB, valueA)
+		default:
+			return cmp.Compare(valueA, valueB)
+		}
+	})
+
+	order := "asc"
+	if descending {
+		order = "desc"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"course_prefix": prefix,
+		"course_number": number,
+		"sort":          sortBy,
+		"order":         order,
+		"count":         len(comparisons),
+		"professors":    comparisons,
+	})
+}
+
+// GetCourseDetail gathers what a course page needs in one call: the course's
+// sections in a term, its grade history across every term, and the ratings of
+// each instructor teaching it.
+func (h *Handler) GetCourseDetail(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	prefix := normalizePrefix(c.Param("prefix"))
+	number := normalizeCourseNumber(c.Param("number"))
+
+	if term == "" || prefix == "" || number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term, prefix, and number parameters are required"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	sections, _, err := h.db.QueryByCourseNumber(ctx, term, prefix, number, storage.Page{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get courses"})
+		return
+	}
+	grades, err := h.courseGrades(ctx, prefix, number, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(sections) == 0 && len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "course not found"})
+		return
+	}
+
+	professors, err := h.professorsTeaching(ctx, sections)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professors"})
+		return
+	}
+	history := make(map[string]gradestats.Summary)
+	for _, summary := range gradestats.ByInstructor(grades) {
+		if summary.InstructorID != "" {
+			history[summary.InstructorID] = summary
+		}
+	}
+
+	// Instructors are listed in the order they first appear on the sections.
+	instructors := []professorComparison{}
+	seen := make(map[string]bool)
+	for _, section := range sections {
+		names := types.SplitList(section.Instructors)
+		for i, id := range storage.InstructorIDList(section.InstructorIDs) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			instructor := professorComparison{InstructorID: id, Terms: []string{}}
+			if i < len(names) {
+				instructor.Name = displayName(names[i])
+			}
+			if summary, ok := history[id]; ok {
+				instructor.setGrades(summary)
+			}
+			if professor, ok := professors[id]; ok {
+				instructor.setProfile(professor, prefix+number)
+			}
+			instructors = append(instructors, instructor)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":          term,
+		"course_prefix": prefix,
+		"course_number": number,
+		"section_count": len(sections),
+		"sections":      sections,
+		"instructors":   instructors,
+		"grades":        gradestats.Summarize(grades),
+		"grade_trend":   gradestats.Trend(grades),
+	})
+}
+
+// GetSection loads one section by its section address, such as cs2305.001.23f.
+func (h *Handler) GetSection(c *gin.Context) {
+	addresses := normalizeSectionAddresses([]string{c.Param("address")})
+	if len(addresses) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Section address is required"})
+		return
+	}
+
+	courses, err := h.db.GetCoursesByAddress(c.Request.Context(), addresses)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get section"})
+		return
+	}
+	if len(courses) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "section not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"course": courses[0],
+	})
+}
+
+// GetSections loads up to maxSectionsPerRequest sections by address in one
+// storage round trip, in request order, and lists the addresses not found.
+func (h *Handler) GetSections(c *gin.Context) {
+	var req struct {
+		Sections []string `json:"sections" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	addresses := normalizeSectionAddresses(req.Sections)
+	if len(addresses) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sections must list at least one section address"})
+		return
+	}
+	if len(addresses) > maxSectionsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("sections cannot list more than %d section addresses", maxSectionsPerRequest)})
+		return
+	}
+
+	courses, notFound, err := h.sectionsInTerm(c.Request.Context(), "", addresses)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get sections"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":     len(courses),
+		"courses":   courses,
+		"not_found": notFound,
+	})
+}
+
+// GetCourseByClassNumber loads the section of a term with the given class number.
+func (h *Handler) GetCourseByClassNumber(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	classNumber := strings.TrimSpace(c.Param("class_number"))
+
+	if term == "" || classNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term and class number parameters are required"})
+		return
+	}
+
+	course, err := h.db.GetCourseByClassNumber(c.Request.Context(), term, classNumber)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "section not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get section"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"course": course,
+	})
+}
+
+// sectionsInTerm loads sections by address, in request order, and returns the
+// addresses that are missing or belong to a different term. An empty term
+// accepts sections of any term.
+func (h *Handler) sectionsInTerm(ctx context.Context, term string, addresses []string) ([]types.Course, []string, error) {
+	found, err := h.db.GetCoursesByAddress(ctx, addresses)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byAddress := make(map[string]types.Course, len(found))
+	for _, course := range found {
+		if term == "" || course.Term == term {
+			byAddress[course.SectionAddress] = course
+		}
+	}
+
+	courses := []types.Course{}
+	notFound := []string{}
+	for _, address := range addresses {
+		if course, ok := byAddress[address]; ok {
+			courses = append(courses, course)
+		} else {
+			notFound = append(notFound, address)
+		}
+	}
+	return courses, notFound, nil
+}
+
+// normalizeSectionAddresses lowercases and trims section addresses, dropping
+// blanks and repeats.
+func normalizeSectionAddresses(values []string) []string {
+	addresses := []string{}
+	seen := make(map[string]bool)
+	for _, value := range values {
+		address := strings.ToLower(strings.TrimSpace(value))
+		if address == "" || seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+func sectionAddresses(courses []types.Course) []string {
+	addresses := make([]string, len(courses))
+	for i, course := range courses {
+		addresses[i] = course.SectionAddress
+	}
+	return addresses
+}
+
+// storageServes reports whether a storage query can answer the filter on its
+// own: a prefix, a prefix and number, a school, or an instructor ID. Anything
+// else is filtered in memory from the term's cached sections.
+func storageServes(filter search.CourseFilter) bool {
+	if filter.InstructorID != "" {
+		rest := filter
+		rest.InstructorID = ""
+		return rest.IsZero()
+	}
+
+	rest := filter
+	rest.Prefix, rest.Number, rest.School = "", "", ""
+	if !rest.IsZero() {
+		return false
+	}
+	if filter.Number != "" && filter.Prefix == "" {
+		return false
+	}
+	return filter.School == "" || (filter.Prefix == "" && filter.Number == "")
+}
+
+// filteredCourses pages a term's sections matching the filter. The listing is
+// ordered by section address and pages with its own cursor kind.
+func (h *Handler) filteredCourses(ctx context.Context, term string, filter search.CourseFilter, page storage.Page) ([]types.Course, string, error) {
+	courses, err := h.search.FilterCourses(ctx, term, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	return storage.PageSorted(courses, page, storage.FilteredCourseCursor, func(course types.Course) []string {
+		return []string{course.SectionAddress}
+	})
+}
+
+// paginateResults slices ranked search results to the requested page.
+func paginateResults[T any](results []T, params paginationParams) ([]T, bool) {
+	if params.Offset >= len(results) {
+		return []T{}, false
+	}
+
+	end := params.Offset + params.Limit
+	if end > len(results) {
+		end = len(results)
+	}
+
+	return results[params.Offset:end], end < len(results)
+}
+
+// GetTerms returns all known terms.
+func (h *Handler) GetTerms(c *gin.Context) {
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
+		return
+	}
+
+	terms, nextCursor, err := h.db.QueryAllTerms(c.Request.Context(), params.storagePage())
+	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	details := make([]types.Term, 0, len(terms))
+	for _, code := range terms {
+		if term, err := types.ParseTerm(code); err == nil {
+			details = append(details, term)
+		}
+	}
+
+	pagination := buildCursorPaginationMeta(params, len(terms), nextCursor)
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":      len(terms),
+		"terms":      terms,
+		"details":    details,
+		"pagination": pagination,
+	})
+}
+
+// GetCurrentTerm returns the most recent term whose classes have started as of
+// today, or as of the optional date query parameter (YYYY-MM-DD).
+func (h *Handler) GetCurrentTerm(c *gin.Context) {
+	date := time.Now()
+	if value := strings.TrimSpace(c.Query("date")); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date parameter must be formatted as YYYY-MM-DD"})
+			return
+		}
+		date = parsed
+	}
+
+	terms, err := h.knownTerms(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(terms) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no terms available"})
+		return
+	}
+
+	current := currentTerm(terms, date)
+
+	c.JSON(http.StatusOK, gin.H{
+		"date":       date.Format("2006-01-02"),
+		"term":       current,
+		"in_session": current.HasStarted(date) && !current.HasEnded(date),
+	})
+}
+
+// GetLatestTerm returns the chronologically last term with data, which may be
+// an upcoming term whose schedule has already been published.
+func (h *Handler) GetLatestTerm(c *gin.Context) {
+	terms, err := h.knownTerms(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(terms) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no terms available"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"term": terms[len(terms)-1]})
+}
+
+// currentTerm returns the last of the chronologically ordered terms whose
+// classes have started by date. Before the first term starts, the first term
+// is the best answer.
+func currentTerm(terms []types.Term, date time.Time) types.Term {
+	current := terms[0]
+	for _, term := range terms {
+		if !term.HasStarted(date) {
+			break
+		}
+		current = term
+	}
+	return current
+}
+
+// knownTerms loads every stored term that parses, in chronological order.
+func (h *Handler) knownTerms(c *gin.Context) ([]types.Term, error) {
+	codes, _, err := h.db.QueryAllTerms(c.Request.Context(), storage.Page{})
+	if err != nil {
+		return nil, err
+	}
+
+	terms := make([]types.Term, 0, len(codes))
+	for _, code := range codes {
+		if term, err := types.ParseTerm(code); err == nil {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms, nil
+}
+
+// GetPrefixesByTerm lists the course prefixes offered in a term.
+func (h *Handler) GetPrefixesByTerm(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	prefixes, err := h.db.GetPrefixesByTerm(c.Request.Context(), term)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if prefixes == nil {
+		prefixes = []string{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":     term,
+		"count":    len(prefixes),
+		"prefixes": prefixes,
+	})
+}
+
+// GetSchoolsByTerm lists the schools offering sections in a term, with their
+// departments and section counts, from the term's cached search index.
+func (h *Handler) GetSchoolsByTerm(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	schools, err := h.search.Schools(c.Request.Context(), term)
+	if err != nil {
+		if respondInvalidTerm(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if schools == nil {
+		schools = []types.SchoolSummary{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":    term,
+		"count":   len(schools),
+		"schools": schools,
+	})
+}
+
+// GetRooms lists the rooms used by a term's sections, optionally within one building.
+func (h *Handler) GetRooms(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
+		return
+	}
+
+	occupancy, err := h.occupancy(c.Request.Context(), term)
+	if err != nil {
+		if respondInvalidTerm(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rooms, nextCursor, err := pageRooms(occupancy.Rooms(c.Query("building")), params)
+	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":       term,
+		"count":      len(rooms),
+		"rooms":      rooms,
+		"pagination": buildCursorPaginationMeta(params, len(rooms), nextCursor),
+	})
+}
+
+// GetRoomSchedule returns a room's weekly meetings in a term.
+func (h *Handler) GetRoomSchedule(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	building := strings.TrimSpace(c.Param("building"))
+	roomNumber := strings.TrimSpace(c.Param("room"))
+	if term == "" || building == "" || roomNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term, building, and room parameters are required"})
+		return
+	}
+
+	occupancy, err := h.occupancy(c.Request.Context(), term)
+	if err != nil {
+		if respondInvalidTerm(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	room, bookings, ok := occupancy.Schedule(building, roomNumber)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "room has no meetings in this term"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":     term,
+		"room":     room,
+		"count":    len(bookings),
+		"meetings": bookings,
+	})
+}
+
+// GetFreeRooms lists the rooms with no meetings during a window on one day.
+func (h *Handler) GetFreeRooms(c *gin.Context) {
+	term := normalizeTerm(c.Param("term"))
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Term parameter is required"})
+		return
+	}
+
+	days := types.ParseDays(c.Query("day"))
+	if len(days) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "day parameter must be a single weekday, e.g. tuesday"})
+		return
+	}
+	start, startOK := types.ParseClock(c.Query("start"))
+	end, endOK := types.ParseClock(c.Query("end"))
+	if !startOK || !endOK {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start and end parameters must be times such as 14:00 or 2:00pm"})
+		return
+	}
+	if start >= end {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start must be earlier than end"})
+		return
+	}
+
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
+		return
+	}
+
+	occupancy, err := h.occupancy(c.Request.Context(), term)
+	if err != nil {
+		if respondInvalidTerm(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rooms, nextCursor, err := pageRooms(occupancy.FreeRooms(days[0], start, end, c.Query("building")), params)
+	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"term":          term,
+		"day":           strings.ToLower(days[0].String()),
+		"start_minutes": start,
+		"end_minutes":   end,
+		"count":         len(rooms),
+		"rooms":         rooms,
+		"pagination":    buildCursorPaginationMeta(params, len(rooms), nextCursor),
+	})
+}
+
+// occupancy indexes the term's cached sections by room.
+func (h *Handler) occupancy(ctx context.Context, term string) (*schedule.Occupancy, error) {
+	courses, err := h.search.FilterCourses(ctx, term, search.CourseFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return schedule.NewOccupancy(courses), nil
+}
+
+func pageRooms(rooms []schedule.Room, params paginationParams) ([]schedule.Room, string, error) {
+	return storage.PageSorted(rooms, params.storagePage(), storage.RoomCursor, func(room schedule.Room) []string {
+		return []string{room.Building, room.Room}
+	})
+}
+
+// CreateAPIKey provisions a new API key.
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var req struct {
+		RateLimit     int    `json:"rate_limit" binding:"required"`
+		WindowSeconds int    `json:"window_seconds" binding:"required"`
+		IsAdmin       bool   `json:"is_admin"`
+		ExpiresAt     string `json:"expires_at"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.RateLimit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "rate limit must be greater than 0"})
+		return
+	}
+
+	if req.WindowSeconds <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "window seconds must be greater than 0"})
+		return
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != "" {
+		var err error
+		expiresAt, err = time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid expires_at format"})
+			return
+		}
+
+		if expiresAt.Before(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expiration date must be in the future"})
+			return
+		}
+	}
+
+	key, err := h.db.GenerateAPIKey(
+		c.Request.Context(),
+		req.RateLimit,
+		req.WindowSeconds,
+		req.IsAdmin,
+		expiresAt,
+	)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create API key"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"key": key})
+}
+
+// GetAPIKey retrieves metadata for a stored API key.
+func (h *Handler) GetAPIKey(c *gin.Context) {
+	key := c.Param("key")
+
+	apiKey, err := h.db.GetAPIKey(c.Request.Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKey)
+}
+
+// directorySorts maps each professor directory sort parameter value to the
+// field it orders by and whether larger values sort first by default. The
+// second result of key reports whether the professor has a value; professors
+// without one always sort last.
+var directorySorts = map[string]struct {
+	key        func(types.Professor) (string, bool)
+	descending bool
+}{
+	"instructor_id": {func(p types.Professor) (string, bool) { return p.InstructorID, true }, false},
+	"name": {func(p types.Professor) (string, bool) {
+		name := strings.ToLower(strings.TrimSpace(p.NormalizedCoursebookName))
+		return name, name != ""
+	}, false},
+	"department": {func(p types.Professor) (string, bool) {
+		department := strings.ToLower(strings.TrimSpace(p.Department))
+		return department, department != ""
+	}, false},
+	"quality":       {func(p types.Professor) (string, bool) { return sortableNumber(p.QualityRating), p.RatingsCount > 0 }, true},
+	"difficulty":    {func(p types.Professor) (string, bool) { return sortableNumber(p.DifficultyRating), p.RatingsCount > 0 }, false},
+	"ratings_count": {func(p types.Professor) (string, bool) { return sortableNumber(float64(p.RatingsCount)), true }, true},
+	"grade_rating": {func(p types.Professor) (string, bool) {
+		return sortableNumber(p.OverallGradeRating), p.TotalGradeCount > 0
+	}, true},
+}
+
+// sortableNumber formats a non-negative number at a fixed width so that string
+// order matches numeric order.
+func sortableNumber(value float64) string {
+	return fmt.Sprintf("%016.4f", max(value, 0))
+}
+
+// ListProfessors lists the professor directory, filtered by department,
+// rating, difficulty, ratings count, tags, and grade rating, in the requested
+// sort order.
+func (h *Handler) ListProfessors(c *gin.Context) {
+	filter, err := parseProfessorFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respondProfessorDirectory(c, filter)
+}
+
+// GetProfessorsByTag lists the professors carrying a RateMyProfessors tag. It
+// accepts the same filters, sorting, and pagination as ListProfessors.
+func (h *Handler) GetProfessorsByTag(c *gin.Context) {
+	tag := strings.TrimSpace(c.Param("tag"))
+	if tag == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Tag is required"})
+		return
+	}
+
+	filter, err := parseProfessorFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Tags = append(filter.Tags, tag)
+
+	h.respondProfessorDirectory(c, filter)
+}
+
+// respondProfessorDirectory lists the professors matching the filter in the
+// sort order and page the query asks for.
+func (h *Handler) respondProfessorDirectory(c *gin.Context, filter search.ProfessorFilter) {
+	sortBy := strings.ToLower(strings.TrimSpace(c.DefaultQuery("sort", "instructor_id")))
+	sortSpec, ok := directorySorts[sortBy]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of instructor_id, name, department, quality, difficulty, ratings_count, or grade_rating"})
+		return
+	}
+	descending := sortSpec.descending
+	switch strings.ToLower(strings.TrimSpace(c.Query("order"))) {
+	case "":
+	case "asc":
+		descending = false
+	case "desc":
+		descending = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "order must be asc or desc"})
+		return
+	}
+	order := "asc"
+	if descending {
+		order = "desc"
+	}
+
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
+		return
+	}
+
+	matches, err := h.search.FilterProfessors(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list professors"})
+		return
+	}
+
+	// Keys are whether the value is missing, the value, and the instructor ID,
+	// which breaks ties in ascending order whichever way the value sorts.
+	key := func(p types.Professor) []string {
+		value, present := sortSpec.key(p)
+		missing := "0"
+		if !present {
+			missing, value = "1", ""
+		}
+		return []string{missing, value, p.InstructorID}
+	}
+	compare := func(a, b []string) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a[1], b[1]); c != 0 {
+			if descending {
+				return -c
+			}
+			return c
+		}
+		return strings.Compare(a[2], b[2])
+	}
+	slices.SortFunc(matches, func(a, b types.Professor) int {
+		return compare(key(a), key(b))
+	})
+
+	kind := storage.ProfessorDirectoryCursor + ":" + sortBy + ":" + order
+	professors, nextCursor, err := storage.PageSortedFunc(matches, params.storagePage(), kind, key, compare)
+	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sort":       sortBy,
+		"order":      order,
+		"count":      len(professors),
+		"total":      len(matches),
+		"professors": professors,
+		"pagination": buildCursorPaginationMeta(params, len(professors), nextCursor),
+	})
+}
+
+// GetProfessorByID loads a professor by ID.
+func (h *Handler) GetProfessorByID(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID is required"})
+		return
+	}
+
+	professor, err := h.db.GetProfessorById(c.Request.Context(), id)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "professor not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"professor": professor,
+	})
+}
+
+// GetProfessorsByRMPID loads the professors matched to a RateMyProfessors
+// profile.
+func (h *Handler) GetProfessorsByRMPID(c *gin.Context) {
+	rmpID := strings.TrimSpace(c.Param("rmp_id"))
+
+	if rmpID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "RateMyProfessors ID is required"})
+		return
+	}
+
+	professors, err := h.db.GetProfessorsByRMPID(c.Request.Context(), rmpID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professors"})
+		return
+	}
+	if len(professors) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "professor not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rmp_id":     rmpID,
+		"count":      len(professors),
+		"professors": professors,
+	})
+}
+
+// GetDepartmentTags reports how often each RateMyProfessors tag is given to
+// the professors of a department.
+func (h *Handler) GetDepartmentTags(c *gin.Context) {
+	department := strings.TrimSpace(c.Param("department"))
+
+	if department == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Department is required"})
 		return
 	}
 
-	terms, hasNext, err := h.db.QueryAllTerms(c.Request.Context(), params.Limit, params.Offset)
+	tags, professors, tagged, err := h.search.ProfessorTagCounts(c.Request.Context(), search.ProfessorFilter{Department: department})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count professor tags"})
+		return
+	}
+	if professors == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "department not found"})
 		return
 	}
-
-	pagination := buildPaginationMeta(params, len(terms), hasNext)
 
 	c.JSON(http.StatusOK, gin.H{
-		"count":      len(terms),
-		"terms":      terms,
-		"pagination": pagination,
+		"department":        department,
+		"professor_count":   professors,
+		"tagged_professors": tagged,
+		"count":             len(tags),
+		"tags":              tags,
 	})
 }
 
-// CreateAPIKey provisions a new API key.
-func (h *Handler) CreateAPIKey(c *gin.Context) {
-	var req struct {
-		RateLimit     int    `json:"rate_limit" binding:"required"`
-		WindowSeconds int    `json:"window_seconds" binding:"required"`
-		IsAdmin       bool   `json:"is_admin"`
-		ExpiresAt     string `json:"expires_at"`
-	}
+// GetProfessorProfile gathers what a professor page needs in one call: the
+// professor, the sections they teach in a term, the courses they have taught,
+// and their combined grade statistics.
+func (h *Handler) GetProfessorProfile(c *gin.Context) {
+	id := c.Param("id")
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID is required"})
 		return
 	}
 
-	if req.RateLimit <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "rate limit must be greater than 0"})
+	ctx := c.Request.Context()
+	professor, err := h.db.GetProfessorById(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "professor not found"})
 		return
 	}
-
-	if req.WindowSeconds <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "window seconds must be greater than 0"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professor"})
 		return
 	}
 
-	var expiresAt time.Time
-	if req.ExpiresAt != "" {
-		var err error
-		expiresAt, err = time.Parse(time.RFC3339, req.ExpiresAt)
+	term := normalizeTerm(c.Query("term"))
+	if term == "" {
+		terms, err := h.knownTerms(c)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid expires_at format"})
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get terms"})
 			return
 		}
+		if len(terms) > 0 {
+			term = currentTerm(terms, time.Now()).Code
+		}
+	}
 
-		if expiresAt.Before(time.Now()) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "expiration date must be in the future"})
+	sections := []types.Course{}
+	if term != "" {
+		sections, err = h.search.FilterCourses(ctx, term, search.CourseFilter{InstructorID: professor.InstructorID})
+		if err != nil {
+			if respondInvalidTerm(c, err) {
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get sections"})
 			return
 		}
 	}
 
-	key, err := h.db.GenerateAPIKey(
-		c.Request.Context(),
-		req.RateLimit,
-		req.WindowSeconds,
-		req.IsAdmin,
-		expiresAt,
-	)
+	grades, _, err := h.db.GetGradesByProfId(ctx, professor.InstructorID, storage.Page{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create API key"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"key": key})
+	c.JSON(http.StatusOK, gin.H{
+		"professor":     professor,
+		"term":          term,
+		"section_count": len(sections),
+		"sections":      sections,
+		"courses":       gradestats.ByCourse(grades),
+		"grades":        gradestats.Summarize(grades),
+	})
 }
 
-// GetAPIKey retrieves metadata for a stored API key.
-func (h *Handler) GetAPIKey(c *gin.Context) {
-	key := c.Param("key")
+// GetProfessorSections lists the sections a professor teaches in a term.
+func (h *Handler) GetProfessorSections(c *gin.Context) {
+	id := strings.TrimSpace(c.Param("id"))
+	term := normalizeTerm(c.Param("term"))
 
-	apiKey, err := h.db.GetAPIKey(c.Request.Context(), key)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get API key"})
+	if id == "" || term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID and term are required"})
 		return
 	}
 
-	c.JSON(http.StatusOK, apiKey)
-}
-
-// GetProfessorByID loads a professor by ID.
-func (h *Handler) GetProfessorByID(c *gin.Context) {
-	id := c.Param("id")
-
-	if id == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID is required"})
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
 		return
 	}
 
-	professor, err := h.db.GetProfessorById(c.Request.Context(), id)
+	courses, nextCursor, err := h.db.QueryByInstructor(c.Request.Context(), term, id, params.storagePage())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professor"})
+		if respondInvalidCursor(c, err) {
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get sections"})
 		return
 	}
 
+	pagination := buildCursorPaginationMeta(params, len(courses), nextCursor)
+
 	c.JSON(http.StatusOK, gin.H{
-		"professor": professor,
+		"instructor_id": id,
+		"term":          term,
+		"count":         len(courses),
+		"courses":       courses,
+		"pagination":    pagination,
 	})
 }
 
@@ -318,16 +1758,56 @@ func (h *Handler) GetProfessorsByName(c *gin.Context) {
 		return
 	}
 
-	professors, hasNext, err := h.db.GetProfessorsByName(c.Request.Context(), name, params.Limit, params.Offset)
+	professors, nextCursor, err := h.db.GetProfessorsByName(c.Request.Context(), name, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get professors"})
 		return
 	}
 
+	pagination := buildCursorPaginationMeta(params, len(professors), nextCursor)
+
+	c.JSON(http.StatusOK, gin.H{
+		"count":      len(professors),
+		"professors": professors,
+		"pagination": pagination,
+	})
+}
+
+// SearchProfessors finds professors by name, instructor ID, or department,
+// tolerating typos and partial names.
+func (h *Handler) SearchProfessors(c *gin.Context) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Search query parameter 'q' is required"})
+		return
+	}
+
+	params, ok := parsePaginationOrRespond(c)
+	if !ok {
+		return
+	}
+
+	if params.Cursor != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cursor parameter is not supported for search; use page instead"})
+		return
+	}
+
+	results, err := h.search.SearchProfessors(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search professors"})
+		return
+	}
+
+	professors, hasNext := paginateResults(results, params)
 	pagination := buildPaginationMeta(params, len(professors), hasNext)
 
 	c.JSON(http.StatusOK, gin.H{
+		"query":      query,
 		"count":      len(professors),
+		"total":      len(results),
 		"professors": professors,
 		"pagination": pagination,
 	})
@@ -347,17 +1827,20 @@ func (h *Handler) GetGradesByProfID(c *gin.Context) {
 		return
 	}
 
-	grades, hasNext, err := h.db.GetGradesByProfId(c.Request.Context(), id, params.Limit, params.Offset)
+	grades, nextCursor, err := h.db.GetGradesByProfId(c.Request.Context(), id, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(grades), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(grades), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"count":      len(grades),
-		"grades":     grades,
+		"grades":     gradestats.Records(grades),
 		"pagination": pagination,
 	})
 }
@@ -376,17 +1859,20 @@ func (h *Handler) GetGradesByProfName(c *gin.Context) {
 		return
 	}
 
-	grades, hasNext, err := h.db.GetGradesByProfName(c.Request.Context(), name, params.Limit, params.Offset)
+	grades, nextCursor, err := h.db.GetGradesByProfName(c.Request.Context(), name, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(grades), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(grades), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"count":      len(grades),
-		"grades":     grades,
+		"grades":     gradestats.Records(grades),
 		"pagination": pagination,
 	})
 }
@@ -405,17 +1891,20 @@ func (h *Handler) GetGradesByPrefix(c *gin.Context) {
 		return
 	}
 
-	grades, hasNext, err := h.db.GetGradesByPrefix(c.Request.Context(), prefix, params.Limit, params.Offset)
+	grades, nextCursor, err := h.db.GetGradesByPrefix(c.Request.Context(), prefix, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(grades), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(grades), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"count":      len(grades),
-		"grades":     grades,
+		"grades":     gradestats.Records(grades),
 		"pagination": pagination,
 	})
 }
@@ -435,17 +1924,20 @@ func (h *Handler) GetGradesByPrefixAndNumber(c *gin.Context) {
 		return
 	}
 
-	grades, hasNext, err := h.db.GetGradesByPrefixAndNumber(c.Request.Context(), prefix, number, params.Limit, params.Offset)
+	grades, nextCursor, err := h.db.GetGradesByPrefixAndNumber(c.Request.Context(), prefix, number, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(grades), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(grades), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"count":      len(grades),
-		"grades":     grades,
+		"grades":     gradestats.Records(grades),
 		"pagination": pagination,
 	})
 }
@@ -465,21 +1957,157 @@ func (h *Handler) GetGradesByPrefixAndTerm(c *gin.Context) {
 		return
 	}
 
-	grades, hasNext, err := h.db.GetGradesByPrefixAndTerm(c.Request.Context(), prefix, term, params.Limit, params.Offset)
+	grades, nextCursor, err := h.db.GetGradesByPrefixAndTerm(c.Request.Context(), prefix, term, params.storagePage())
 	if err != nil {
+		if respondInvalidCursor(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
 		return
 	}
 
-	pagination := buildPaginationMeta(params, len(grades), hasNext)
+	pagination := buildCursorPaginationMeta(params, len(grades), nextCursor)
 
 	c.JSON(http.StatusOK, gin.H{
 		"count":      len(grades),
-		"grades":     grades,
+		"grades":     gradestats.Records(grades),
 		"pagination": pagination,
 	})
 }
 
+// GetCourseGradeStats rolls up a course's grade distributions across every
+// term and instructor, with a summary per instructor.
+func (h *Handler) GetCourseGradeStats(c *gin.Context) {
+	prefix := c.Param("prefix")
+	number := c.Param("number")
+
+	if prefix == "" || number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Prefix and number are required"})
+		return
+	}
+
+	grades, _, err := h.db.GetGradesByPrefixAndNumber(c.Request.Context(), prefix, number, storage.Page{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no grades found for this course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"course":      gradestats.Summarize(grades),
+		"instructors": gradestats.ByInstructor(grades),
+	})
+}
+
+// GetProfessorGradeStats rolls up a professor's grade distributions across
+// every term and course, with a summary per course.
+func (h *Handler) GetProfessorGradeStats(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID is required"})
+		return
+	}
+
+	grades, _, err := h.db.GetGradesByProfId(c.Request.Context(), id, storage.Page{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no grades found for this professor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"professor": gradestats.Summarize(grades),
+		"courses":   gradestats.ByCourse(grades),
+	})
+}
+
+// GetProfessorCourseGradeStats rolls up a professor's grade distributions for
+// one course across every term they taught it.
+func (h *Handler) GetProfessorCourseGradeStats(c *gin.Context) {
+	id := c.Param("id")
+	prefix := normalizePrefix(c.Param("prefix"))
+	number := normalizeCourseNumber(c.Param("number"))
+
+	if id == "" || prefix == "" || number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Professor ID, prefix, and number are required"})
+		return
+	}
+
+	grades, err := h.courseGrades(c.Request.Context(), prefix, number, id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no grades found for this professor and course"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"summary": gradestats.Summarize(grades),
+	})
+}
+
+// GetCourseGradeTrend reports a course's grade distribution term by term,
+// oldest first, optionally limited to one instructor.
+func (h *Handler) GetCourseGradeTrend(c *gin.Context) {
+	prefix := normalizePrefix(c.Param("prefix"))
+	number := normalizeCourseNumber(c.Param("number"))
+	instructorID := strings.TrimSpace(c.Query("instructor_id"))
+
+	if prefix == "" || number == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Prefix and number are required"})
+		return
+	}
+
+	grades, err := h.courseGrades(c.Request.Context(), prefix, number, instructorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get grades"})
+		return
+	}
+	if len(grades) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no grades found for this course"})
+		return
+	}
+
+	trend := gradestats.Trend(grades)
+	response := gin.H{
+		"course_prefix": prefix,
+		"course_number": number,
+		"count":         len(trend),
+		"trend":         trend,
+	}
+	if instructorID != "" {
+		response["instructor_id"] = instructorID
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// courseGrades loads every grade record of a course. A non-empty instructorID
+// narrows the records to that instructor, which is read from the instructor's
+// records since they are far fewer than the course's.
+func (h *Handler) courseGrades(ctx context.Context, prefix, number, instructorID string) ([]types.Grades, error) {
+	if instructorID == "" {
+		grades, _, err := h.db.GetGradesByPrefixAndNumber(ctx, prefix, number, storage.Page{})
+		return grades, err
+	}
+
+	grades, _, err := h.db.GetGradesByProfId(ctx, instructorID, storage.Page{})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(grades, func(grade types.Grades) bool {
+		return !strings.EqualFold(grade.CoursePrefix, prefix) || !strings.EqualFold(grade.CourseNumber, number)
+	}), nil
+}
+
 func normalizeTerm(value string) string {
 	return strings.ToLower(strings.TrimSpace(value))
 }
@@ -492,6 +2120,133 @@ func normalizeCourseNumber(value string) string {
 	return strings.ToLower(strings.TrimSpace(value))
 }
 
+// parseCourseFilter reads the course filter query parameters shared by the
+// course listing and search endpoints.
+func parseCourseFilter(c *gin.Context) (search.CourseFilter, error) {
+	filter := search.CourseFilter{
+		Prefix:       normalizePrefix(c.Query("prefix")),
+		Number:       normalizeCourseNumber(c.Query("number")),
+		School:       strings.TrimSpace(c.Query("school")),
+		InstructorID: strings.TrimSpace(c.Query("instructor_id")),
+		ActivityType: strings.TrimSpace(c.Query("activity_type")),
+		CoreArea:     strings.TrimSpace(c.Query("core_area")),
+		Dept:         strings.TrimSpace(c.Query("dept")),
+		Session:      strings.TrimSpace(c.Query("session")),
+	}
+
+	if value := strings.TrimSpace(c.Query("open")); value != "" {
+		open, err := strconv.ParseBool(value)
+		if err != nil {
+			return search.CourseFilter{}, fmt.Errorf("open parameter must be true or false")
+		}
+		filter.OpenOnly = open
+	}
+
+	if value := strings.TrimSpace(c.Query("days")); value != "" {
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool { return !unicode.IsLetter(r) }) {
+			if len(types.ParseDays(word)) == 0 {
+				return search.CourseFilter{}, fmt.Errorf("days parameter must list weekdays, e.g. MWF or monday,wednesday")
+			}
+		}
+		filter.Days = types.ParseDays(value)
+	}
+
+	var err error
+	if filter.StartAfter, err = parseTimeBound("start_after parameter", c.Query("start_after")); err != nil {
+		return search.CourseFilter{}, err
+	}
+	if filter.EndBefore, err = parseTimeBound("end_before parameter", c.Query("end_before")); err != nil {
+		return search.CourseFilter{}, err
+	}
+	if filter.StartAfter != nil && filter.EndBefore != nil && *filter.StartAfter >= *filter.EndBefore {
+		return search.CourseFilter{}, fmt.Errorf("start_after must be earlier than end_before")
+	}
+
+	if value := strings.TrimSpace(c.Query("level")); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 1000 || level > 9000 || level%1000 != 0 {
+			return search.CourseFilter{}, fmt.Errorf("level parameter must be a course level such as 1000 or 3000")
+		}
+		filter.Level = level / 1000
+	}
+
+	if value := strings.TrimSpace(c.Query("modality")); value != "" {
+		modality := search.Modality(strings.ReplaceAll(strings.ToLower(value), "-", "_"))
+		if modality != search.ModalityOnline && modality != search.ModalityInPerson {
+			return search.CourseFilter{}, fmt.Errorf("modality parameter must be online or in_person")
+		}
+		filter.Modality = modality
+	}
+
+	return filter, nil
+}
+
+// parseTimeBound parses an optional time of day into minutes after midnight.
+// An empty value returns nil, leaving the bound unset, so midnight ("00:00")
+// remains a bound of its own.
+func parseTimeBound(name, value string) (*int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	minutes, ok := types.ParseClock(value)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a time such as 10:00, 13:30, or 1:30pm", name)
+	}
+	return &minutes, nil
+}
+
+func parseProfessorFilter(c *gin.Context) (search.ProfessorFilter, error) {
+	filter := search.ProfessorFilter{
+		Department: strings.TrimSpace(c.Query("department")),
+		Tags:       types.SplitList(c.Query("tags")),
+	}
+
+	bounds := []struct {
+		name  string
+		limit float64
+		value *float64
+	}{
+		{"min_quality", 5, &filter.MinQuality},
+		{"max_difficulty", 5, &filter.MaxDifficulty},
+		{"min_grade_rating", 4, &filter.MinGradeRating},
+		{"max_grade_rating", 4, &filter.MaxGradeRating},
+	}
+	for _, bound := range bounds {
+		value := strings.TrimSpace(c.Query(bound.name))
+		if value == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || number < 0 || number > bound.limit {
+			return search.ProfessorFilter{}, fmt.Errorf("%s parameter must be a number from 0 to %g", bound.name, bound.limit)
+		}
+		*bound.value = number
+	}
+	if filter.MaxGradeRating > 0 && filter.MinGradeRating > filter.MaxGradeRating {
+		return search.ProfessorFilter{}, fmt.Errorf("min_grade_rating must not exceed max_grade_rating")
+	}
+
+	if value := strings.TrimSpace(c.Query("min_ratings")); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
+			return search.ProfessorFilter{}, fmt.Errorf("min_ratings parameter must be a non-negative integer")
+		}
+		filter.MinRatings = count
+	}
+
+	return filter, nil
+}
+
+func parseCourseFilterOrRespond(c *gin.Context) (search.CourseFilter, bool) {
+	filter, err := parseCourseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return search.CourseFilter{}, false
+	}
+	return filter, true
+}
+
 func parsePaginationParams(c *gin.Context) (paginationParams, error) {
 	limitValue := strings.TrimSpace(c.Query("limit"))
 	if limitValue == "" {
@@ -522,6 +2277,7 @@ func parsePaginationParams(c *gin.Context) (paginationParams, error) {
 		Limit:  limit,
 		Page:   page,
 		Offset: offset,
+		Cursor: strings.TrimSpace(c.Query("cursor")),
 	}, nil
 }
 
@@ -549,3 +2305,45 @@ func buildPaginationMeta(params paginationParams, itemsReturned int, hasNext boo
 
 	return meta
 }
+
+// buildCursorPaginationMeta adds cursor tokens to the page metadata. When the
+// request itself used a cursor, page numbers and totals are unknown and omitted.
+func buildCursorPaginationMeta(params paginationParams, itemsReturned int, nextCursor string) gin.H {
+	hasNext := nextCursor != ""
+	if params.Cursor == "" {
+		meta := buildPaginationMeta(params, itemsReturned, hasNext)
+		if hasNext {
+			meta["next_cursor"] = nextCursor
+		}
+		return meta
+	}
+
+	meta := gin.H{
+		"limit":    params.Limit,
+		"cursor":   params.Cursor,
+		"has_next": hasNext,
+	}
+	if hasNext {
+		meta["next_cursor"] = nextCursor
+	}
+
+	return meta
+}
+
+// respondInvalidCursor reports a malformed cursor as a client error.
+func respondInvalidCursor(c *gin.Context, err error) bool {
+	if !errors.Is(err, storage.ErrInvalidCursor) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "cursor parameter is invalid"})
+	return true
+}
+
+// respondInvalidTerm reports a term the search service rejected as a client error.
+func respondInvalidTerm(c *gin.Context, err error) bool {
+	if !errors.Is(err, search.ErrInvalidTerm) {
+		return false
+	}
+	c.JSON(http.StatusBadRequest, gin.H{"error": "term parameter must be a term code such as 24f"})
+	return true
+}
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/search"
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+	"github.com/acmutd/acmutd-api/internal/types"
+	"github.com/gin-gonic/gin"
+)
+
+// testContext returns a gin context for a GET request with the given query string.
+func testContext(query string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/?"+query, nil)
+	return c
+}
+
+func minutes(m int) *int {
+	return &m
+}
+
+func TestParseCourseFilter(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    search.CourseFilter
+		wantErr string
+	}{
+		{"", search.CourseFilter{}, ""},
+		{"prefix=CS&number=3345", search.CourseFilter{Prefix: "cs", Number: "3345"}, ""},
+		{"open=true&level=3000&modality=in-person", search.CourseFilter{OpenOnly: true, Level: 3, Modality: search.ModalityInPerson}, ""},
+		{"days=MW", search.CourseFilter{Days: []time.Weekday{time.Monday, time.Wednesday}}, ""},
+		{"start_after=10:00&end_before=5pm", search.CourseFilter{StartAfter: minutes(600), EndBefore: minutes(1020)}, ""},
+		// Midnight is a bound like any other rather than "no bound".
+		{"end_before=00:00", search.CourseFilter{EndBefore: minutes(0)}, ""},
+		{"start_after=00:00", search.CourseFilter{StartAfter: minutes(0)}, ""},
+		{"start_after=noon", search.CourseFilter{}, "start_after parameter must be a time"},
+		{"end_before=noon", search.CourseFilter{}, "end_before parameter must be a time"},
+		// With both bounds invalid, start_after is always the one reported.
+		{"end_before=late&start_after=early", search.CourseFilter{}, "start_after parameter must be a time"},
+		{"start_after=14:00&end_before=14:00", search.CourseFilter{}, "start_after must be earlier than end_before"},
+		{"start_after=00:00&end_before=00:00", search.CourseFilter{}, "start_after must be earlier than end_before"},
+		{"open=maybe", search.CourseFilter{}, "open parameter"},
+		{"days=MX", search.CourseFilter{}, "days parameter"},
+		{"level=3500", search.CourseFilter{}, "level parameter"},
+		{"modality=hybrid", search.CourseFilter{}, "modality parameter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := parseCourseFilter(testContext(tt.query))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filter = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStorageServes(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter search.CourseFilter
+		want   bool
+	}{
+		{"no filter", search.CourseFilter{}, true},
+		{"prefix", search.CourseFilter{Prefix: "cs"}, true},
+		{"prefix and number", search.CourseFilter{Prefix: "cs", Number: "3345"}, true},
+		{"school", search.CourseFilter{School: "ECS"}, true},
+		{"instructor", search.CourseFilter{InstructorID: "jxd123456"}, true},
+		{"number without prefix", search.CourseFilter{Number: "3345"}, false},
+		{"school and prefix", search.CourseFilter{School: "ECS", Prefix: "cs"}, false},
+		{"instructor and prefix", search.CourseFilter{InstructorID: "jxd123456", Prefix: "cs"}, false},
+		{"open seats", search.CourseFilter{OpenOnly: true}, false},
+		{"prefix and midnight bound", search.CourseFilter{Prefix: "cs", EndBefore: minutes(0)}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := storageServes(tt.filter); got != tt.want {
+				t.Errorf("storageServes(%+v) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilteredCourses(t *testing.T) {
+	db := memory.NewFromFixtures(&storage.Fixtures{Courses: []types.Course{
+		{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f", Days: "Monday, Wednesday", Times: "10:00 - 11:15", EnrolledCurrent: "30", EnrolledMax: "60"},
+		{SectionAddress: "cs3345.002.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "002", Term: "24f", Days: "Tuesday, Thursday", Times: "16:00 - 17:15", EnrolledCurrent: "60", EnrolledMax: "60"},
+		{SectionAddress: "cs3354.001.24f", CoursePrefix: "cs", CourseNumber: "3354", Section: "001", Term: "24f", Days: "Monday, Wednesday", Times: "13:00 - 14:15", EnrolledCurrent: "10", EnrolledMax: "60"},
+		{SectionAddress: "cs3354.0w1.24f", CoursePrefix: "cs", CourseNumber: "3354", Section: "0w1", Term: "24f", EnrolledCurrent: "10", EnrolledMax: "60"},
+	}})
+	h := New(db, search.NewService(db, time.Minute))
+	ctx := context.Background()
+
+	list := func(filter search.CourseFilter, page storage.Page) ([]string, string) {
+		t.Helper()
+		courses, next, err := h.filteredCourses(ctx, "24f", filter, page)
+		if err != nil {
+			t.Fatalf("filteredCourses(%+v): %v", filter, err)
+		}
+		var addresses []string
+		for _, course := range courses {
+			addresses = append(addresses, course.SectionAddress)
+		}
+		return addresses, next
+	}
+
+	tests := []struct {
+		name   string
+		filter search.CourseFilter
+		want   []string
+	}{
+		{"open seats", search.CourseFilter{OpenOnly: true}, []string{"cs3345.001.24f", "cs3354.001.24f", "cs3354.0w1.24f"}},
+		{"end before 3pm", search.CourseFilter{EndBefore: minutes(15 * 60)}, []string{"cs3345.001.24f", "cs3354.001.24f"}},
+		{"start after noon", search.CourseFilter{StartAfter: minutes(12 * 60)}, []string{"cs3345.002.24f", "cs3354.001.24f"}},
+		{"end before midnight", search.CourseFilter{EndBefore: minutes(0)}, nil},
+		{"start after midnight", search.CourseFilter{StartAfter: minutes(0)}, []string{"cs3345.001.24f", "cs3345.002.24f", "cs3354.001.24f"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := list(tt.filter, storage.Page{}); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Filtered listings page by cursor in section address order.
+	filter := search.CourseFilter{OpenOnly: true}
+	first, next := list(filter, storage.Page{Limit: 2})
+	if !slices.Equal(first, []string{"cs3345.001.24f", "cs3354.001.24f"}) || next == "" {
+		t.Fatalf("first page = %v, %q; want two sections and a cursor", first, next)
+	}
+	second, next := list(filter, storage.Page{Limit: 2, Cursor: next})
+	if !slices.Equal(second, []string{"cs3354.0w1.24f"}) || next != "" {
+		t.Errorf("second page = %v, %q; want the last section and no cursor", second, next)
+	}
+}
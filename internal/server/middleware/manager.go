@@ -5,8 +5,8 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/acmutd/acmutd-api/internal/firebase"
 	"github.com/acmutd/acmutd-api/internal/server/ratelimit"
+	"github.com/acmutd/acmutd-api/internal/storage"
 	"github.com/acmutd/acmutd-api/internal/types"
 	"github.com/gin-gonic/gin"
 	"github.com/patrickmn/go-cache"
@@ -14,14 +14,14 @@ import (
 
 // Manager wires all HTTP middlewares with shared dependencies.
 type Manager struct {
-	db          *firebase.Firestore
+	db          storage.APIKeyStore
 	apiKeyCache *cache.Cache
 	rateLimiter *ratelimit.Limiter
 	adminKey    string
 }
 
 // NewManager builds a middleware manager for the HTTP server.
-func NewManager(db *firebase.Firestore, apiKeyCache *cache.Cache, limiter *ratelimit.Limiter, adminKey string) *Manager {
+func NewManager(db storage.APIKeyStore, apiKeyCache *cache.Cache, limiter *ratelimit.Limiter, adminKey string) *Manager {
 	return &Manager{
 		db:          db,
 		apiKeyCache: apiKeyCache,
@@ -30,18 +30,49 @@ func New(handler *handlers.Handler, mw *middleware.Manager) http.Handler {
 			courses.GET("/:term", handler.GetCoursesByTerm)
 			courses.GET("/:term/prefix/:prefix", handler.GetCoursesByPrefix)
 			courses.GET("/:term/prefix/:prefix/number/:number", handler.GetCoursesByNumber)
+			courses.GET("/:term/prefix/:prefix/number/:number/detail", handler.GetCourseDetail)
+			courses.GET("/:term/class/:class_number", handler.GetCourseByClassNumber)
 			courses.GET("/:term/search", handler.SearchCourses)
+			courses.POST("/:term/conflicts", handler.CheckConflicts)
+			courses.POST("/:term/schedules", handler.GenerateSchedules)
+			courses.GET("/:term/calendar.ics", handler.ExportCalendar)
+			// The first segment is a course prefix here; see GetCourseProfessors.
+			courses.GET("/:term/:number/professors", handler.GetCourseProfessors)
+		}
+
+		sections := v1.Group("/sections")
+		{
+			sections.GET("/:address", handler.GetSection)
+			sections.POST("/batch", handler.GetSections)
 		}
 
 		terms := v1.Group("/terms")
 		{
 			terms.GET("/", handler.GetTerms)
+			terms.GET("/current", handler.GetCurrentTerm)
+			terms.GET("/latest", handler.GetLatestTerm)
+			terms.GET("/:term/prefixes", handler.GetPrefixesByTerm)
+			terms.GET("/:term/schools", handler.GetSchoolsByTerm)
+		}
+
+		rooms := v1.Group("/rooms")
+		{
+			rooms.GET("/:term", handler.GetRooms)
+			rooms.GET("/:term/free", handler.GetFreeRooms)
+			rooms.GET("/:term/building/:building/room/:room", handler.GetRoomSchedule)
 		}
 
 		professors := v1.Group("/professors")
 		{
+			professors.GET("/", handler.ListProfessors)
 			professors.GET("/id/:id", handler.GetProfessorByID)
+			professors.GET("/id/:id/profile", handler.GetProfessorProfile)
+			professors.GET("/id/:id/sections/:term", handler.GetProfessorSections)
 			professors.GET("/name/:name", handler.GetProfessorsByName)
+			professors.GET("/rmp/:rmp_id", handler.GetProfessorsByRMPID)
+			professors.GET("/tags/:tag", handler.GetProfessorsByTag)
+			professors.GET("/departments/:department/tags", handler.GetDepartmentTags)
+			professors.GET("/search", handler.SearchProfessors)
 		}
 
 		grades := v1.Group("/grades")
@@ -51,6 +82,21 @@ func New(handler *handlers.Handler, mw *middleware.Manager) http.Handler {
 			grades.GET("/prefix/:prefix", handler.GetGradesByPrefix)
 			grades.GET("/prefix/:prefix/number/:number", handler.GetGradesByPrefixAndNumber)
 			grades.GET("/prefix/:prefix/term/:term", handler.GetGradesByPrefixAndTerm)
+			grades.GET("/stats/prefix/:prefix/number/:number", handler.GetCourseGradeStats)
+			grades.GET("/stats/prof/id/:id", handler.GetProfessorGradeStats)
+			grades.GET("/stats/prof/id/:id/prefix/:prefix/number/:number", handler.GetProfessorCourseGradeStats)
+			grades.GET("/trend/prefix/:prefix/number/:number", handler.GetCourseGradeTrend)
+		}
+	}
+
+	v2 := router.Group("/api/v2")
+	v2.Use(mw.Auth(), mw.RateLimit())
+	{
+		courses := v2.Group("/courses")
+		{
+			courses.GET("/:term", handler.GetCoursesByTermV2)
+			courses.GET("/:term/prefix/:prefix", handler.GetCoursesByPrefixV2)
+			courses.GET("/:term/prefix/:prefix/number/:number", handler.GetCoursesByNumberV2)
 		}
 	}
 
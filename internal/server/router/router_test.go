@@ -0,0 +1,429 @@
+package router_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/search"
+	"github.com/acmutd/acmutd-api/internal/server/handlers"
+	"github.com/acmutd/acmutd-api/internal/server/middleware"
+	"github.com/acmutd/acmutd-api/internal/server/ratelimit"
+	"github.com/acmutd/acmutd-api/internal/server/router"
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+	"github.com/acmutd/acmutd-api/internal/types"
+	"github.com/gin-gonic/gin"
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	testKey    = "test-key"
+	expiredKey = "expired-key"
+	adminKey   = "admin-key"
+)
+
+// newTestRouter serves the full router from an in-memory store seeded with
+// three sections, one professor, and a valid and an expired API key.
+func newTestRouter(t *testing.T) http.Handler {
+	return newRouterWith(t, &storage.Fixtures{
+		Courses: []types.Course{
+			{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f", InstructorIDs: "jxd123456"},
+			{SectionAddress: "cs3345.002.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "002", Term: "24f", InstructorIDs: "jxd123456"},
+			{SectionAddress: "cs3354.001.24f", CoursePrefix: "cs", CourseNumber: "3354", Section: "001", Term: "24f"},
+		},
+		Professors: []types.Professor{
+			{InstructorID: "jxd123456", NormalizedCoursebookName: "jane doe", Department: "Computer Science"},
+		},
+	})
+}
+
+// newRouterWith serves the full router from an in-memory store seeded with
+// fixtures plus a valid and an expired API key.
+func newRouterWith(t *testing.T, fixtures *storage.Fixtures) http.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	fixtures.APIKeys = append(fixtures.APIKeys,
+		types.APIKey{Key: testKey, RateLimit: 1000, WindowSeconds: 60, ExpiresAt: time.Now().Add(time.Hour)},
+		types.APIKey{Key: expiredKey, RateLimit: 1000, WindowSeconds: 60, ExpiresAt: time.Now().Add(-time.Hour)},
+	)
+
+	db := memory.NewFromFixtures(fixtures)
+	handler := handlers.New(db, search.NewService(db, time.Minute))
+	mw := middleware.NewManager(db, cache.New(time.Minute, time.Minute), ratelimit.NewLimiter(), adminKey)
+	return router.New(handler, mw)
+}
+
+// get issues a GET request with key as the API key.
+func get(t *testing.T, r http.Handler, path, key string) (int, map[string]any) {
+	t.Helper()
+	return serve(t, r, httptest.NewRequest(http.MethodGet, path, nil), key)
+}
+
+// post issues a POST request with a JSON body using the test API key and
+// decodes the JSON response.
+func post(t *testing.T, r http.Handler, path, body string) (int, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return serve(t, r, req, testKey)
+}
+
+// serve sends req, with key as the API key when it is not empty, and decodes
+// the JSON response.
+func serve(t *testing.T, r http.Handler, req *http.Request, key string) (int, map[string]any) {
+	t.Helper()
+
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s %s: invalid JSON response %q: %v", req.Method, req.URL, rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+func TestAPIKeyRequired(t *testing.T) {
+	r := newTestRouter(t)
+
+	tests := []struct {
+		name string
+		path string
+		key  string
+		want int
+	}{
+		{"health needs no key", "/health", "", http.StatusOK},
+		{"missing key", "/api/v1/terms/", "", http.StatusUnauthorized},
+		{"unknown key", "/api/v1/terms/", "not-a-key", http.StatusUnauthorized},
+		{"expired key", "/api/v1/terms/", expiredKey, http.StatusUnauthorized},
+		{"valid key", "/api/v1/terms/", testKey, http.StatusOK},
+		{"admin route with a regular key", "/admin/apikeys/" + testKey, testKey, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, body := get(t, r, tt.path, tt.key); code != tt.want {
+				t.Errorf("GET %s = %d %v, want %d", tt.path, code, body, tt.want)
+			}
+		})
+	}
+}
+
+func TestCourseListingCursorPagination(t *testing.T) {
+	r := newTestRouter(t)
+
+	code, body := get(t, r, "/api/v1/courses/24f?limit=2", testKey)
+	if code != http.StatusOK {
+		t.Fatalf("first page = %d %v, want 200", code, body)
+	}
+	if courses := body["courses"].([]any); len(courses) != 2 {
+		t.Fatalf("first page has %d courses, want 2", len(courses))
+	}
+	pagination := body["pagination"].(map[string]any)
+	cursor, _ := pagination["next_cursor"].(string)
+	if cursor == "" {
+		t.Fatalf("first page pagination %v has no next_cursor", pagination)
+	}
+
+	code, body = get(t, r, "/api/v1/courses/24f?limit=2&cursor="+cursor, testKey)
+	if code != http.StatusOK {
+		t.Fatalf("second page = %d %v, want 200", code, body)
+	}
+	courses := body["courses"].([]any)
+	if len(courses) != 1 {
+		t.Fatalf("second page has %d courses, want 1", len(courses))
+	}
+	if address := courses[0].(map[string]any)["section_address"]; address != "cs3354.001.24f" {
+		t.Errorf("second page starts at %v, want cs3354.001.24f", address)
+	}
+	if next, _ := body["pagination"].(map[string]any)["next_cursor"].(string); next != "" {
+		t.Errorf("last page has next_cursor %q, want none", next)
+	}
+
+	if code, body := get(t, r, "/api/v1/courses/24f?cursor=not-a-cursor", testKey); code != http.StatusBadRequest {
+		t.Errorf("malformed cursor = %d %v, want 400", code, body)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	r := newTestRouter(t)
+
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"professor", "/api/v1/professors/id/nobody"},
+		{"section", "/api/v1/sections/cs9999.001.24f"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, body := get(t, r, tt.path, testKey); code != http.StatusNotFound {
+				t.Errorf("GET %s = %d %v, want 404", tt.path, code, body)
+			}
+		})
+	}
+
+	code, body := get(t, r, "/api/v1/professors/id/jxd123456", testKey)
+	if code != http.StatusOK {
+		t.Fatalf("existing professor = %d %v, want 200", code, body)
+	}
+}
+
+func TestSearchPagesByNumberOnly(t *testing.T) {
+	r := newTestRouter(t)
+
+	code, body := get(t, r, "/api/v1/courses/24f/search?q=cs&limit=1", testKey)
+	if code != http.StatusOK {
+		t.Fatalf("search = %d %v, want 200", code, body)
+	}
+	pagination := body["pagination"].(map[string]any)
+	if pagination["next_page"] != float64(2) {
+		t.Errorf("search pagination %v, want next_page 2", pagination)
+	}
+	if _, ok := pagination["next_cursor"]; ok {
+		t.Errorf("search pagination %v has a next_cursor", pagination)
+	}
+
+	for _, path := range []string{
+		"/api/v1/courses/24f/search?q=cs&cursor=abc",
+		"/api/v1/professors/search?q=jane&cursor=abc",
+	} {
+		if code, body := get(t, r, path, testKey); code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d %v, want 400", path, code, body)
+		}
+	}
+}
+
+// scheduleFixtures offers courses cs1000 through cs1007, each with three
+// sections meeting Monday in an hour of their own, and cs1337 with a lecture
+// and a lab.
+func scheduleFixtures() *storage.Fixtures {
+	var courses []types.Course
+	for i := range 8 {
+		number := fmt.Sprintf("%d", 1000+i)
+		for section := 1; section <= 3; section++ {
+			courses = append(courses, types.Course{
+				SectionAddress: fmt.Sprintf("cs%s.%03d.24f", number, section),
+				CoursePrefix:   "cs",
+				CourseNumber:   number,
+				Section:        fmt.Sprintf("%03d", section),
+				Term:           "24f",
+				Days:           "Monday",
+				Times:          fmt.Sprintf("%02d:00 - %02d:50", 8+i, 8+i),
+			})
+		}
+	}
+	courses = append(courses,
+		types.Course{SectionAddress: "cs1337.001.24f", CoursePrefix: "cs", CourseNumber: "1337", Section: "001", Term: "24f",
+			ActivityType: "Lecture", Days: "Tuesday", Times: "10:00 - 11:15"},
+		types.Course{SectionAddress: "cs1337.101.24f", CoursePrefix: "cs", CourseNumber: "1337", Section: "101", Term: "24f",
+			ActivityType: "Laboratory", Days: "Thursday", Times: "10:00 - 11:15"},
+	)
+	return &storage.Fixtures{Courses: courses}
+}
+
+func TestGenerateSchedulesValidation(t *testing.T) {
+	r := newRouterWith(t, scheduleFixtures())
+
+	nine := make([]string, 9)
+	for i := range nine {
+		nine[i] = fmt.Sprintf(`{"prefix": "cs", "number": "%d"}`, 1000+i)
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"malformed JSON", `{"courses": [`, ""},
+		{"no courses", `{}`, ""},
+		{"course without a number", `{"courses": [{"prefix": "cs"}]}`, "each course requires a prefix and number"},
+		{"too many courses", `{"courses": [` + strings.Join(nine, ",") + `]}`, "courses must list between 1 and 8 courses"},
+		{"bad earliest_start", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"earliest_start": "noon"}}`, "earliest_start must be a time"},
+		{"bad latest_end", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"latest_end": "late"}}`, "latest_end must be a time"},
+		// With both bounds invalid, earliest_start is always the one reported.
+		{"both bounds bad", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"latest_end": "late", "earliest_start": "noon"}}`, "earliest_start must be a time"},
+		{"empty window", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"earliest_start": "10:00", "latest_end": "10:00"}}`, "earliest_start must be earlier than latest_end"},
+		{"bad day off", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"days_off": ["funday"]}}`, "is not a weekday"},
+		{"rating out of range", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"min_rating": 6}}`, "min_rating must be between 0 and 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := post(t, r, "/api/v1/courses/24f/schedules", tt.body)
+			if code != http.StatusBadRequest {
+				t.Fatalf("status = %d %v, want 400", code, body)
+			}
+			if message, _ := body["error"].(string); !strings.Contains(message, tt.wantErr) {
+				t.Errorf("error = %q, want one containing %q", message, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSchedules(t *testing.T) {
+	r := newRouterWith(t, scheduleFixtures())
+
+	t.Run("budget exceeded", func(t *testing.T) {
+		// Eight courses of three compatible sections make 3^8 schedules, more
+		// than the generator considers.
+		var courses []string
+		for i := range 8 {
+			courses = append(courses, fmt.Sprintf(`{"prefix": "cs", "number": "%d"}`, 1000+i))
+		}
+		code, body := post(t, r, "/api/v1/courses/24f/schedules", `{"courses": [`+strings.Join(courses, ",")+`], "limit": 3}`)
+		if code != http.StatusOK {
+			t.Fatalf("status = %d %v, want 200", code, body)
+		}
+		if body["truncated"] != true || body["count"] != float64(3) {
+			t.Errorf("truncated = %v, count = %v; want a truncated response with 3 schedules", body["truncated"], body["count"])
+		}
+	})
+
+	t.Run("midnight latest_end", func(t *testing.T) {
+		code, body := post(t, r, "/api/v1/courses/24f/schedules", `{"courses": [{"prefix": "cs", "number": "1000"}], "constraints": {"latest_end": "00:00"}}`)
+		if code != http.StatusOK {
+			t.Fatalf("status = %d %v, want 200", code, body)
+		}
+		if unsatisfiable := body["unsatisfiable"].([]any); len(unsatisfiable) != 1 || body["count"] != float64(0) {
+			t.Errorf("unsatisfiable = %v, count = %v; want cs1000 unsatisfiable and no schedules", unsatisfiable, body["count"])
+		}
+	})
+
+	t.Run("unknown course", func(t *testing.T) {
+		code, body := post(t, r, "/api/v1/courses/24f/schedules", `{"courses": [{"prefix": "cs", "number": "1000"}, {"prefix": "cs", "number": "9999"}]}`)
+		if code != http.StatusOK {
+			t.Fatalf("status = %d %v, want 200", code, body)
+		}
+		if notFound := body["not_found"].([]any); len(notFound) != 1 || body["count"] != float64(0) {
+			t.Errorf("not_found = %v, count = %v; want cs9999 not found and no schedules", notFound, body["count"])
+		}
+	})
+
+	t.Run("lecture and lab", func(t *testing.T) {
+		code, body := post(t, r, "/api/v1/courses/24f/schedules", `{"courses": [{"prefix": "cs", "number": "1337"}]}`)
+		if code != http.StatusOK {
+			t.Fatalf("status = %d %v, want 200", code, body)
+		}
+		schedules := body["schedules"].([]any)
+		if len(schedules) != 1 || len(schedules[0].(map[string]any)["sections"].([]any)) != 2 {
+			t.Fatalf("schedules = %v, want one schedule with the lecture and the lab", schedules)
+		}
+		notes := body["notes"].([]any)
+		if len(notes) != 1 || !strings.Contains(notes[0].(string), "CS 1337: Lecture, Laboratory") {
+			t.Errorf("notes = %v, want a note that cs1337's lecture and lab are paired independently", notes)
+		}
+	})
+}
+
+func TestCourseProfessors(t *testing.T) {
+	r := newRouterWith(t, &storage.Fixtures{
+		Grades: []types.Grades{
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f", InstructorID: "aaa", Instructor1: "Adams, Ann", A: "10"},
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "002", Term: "24f", InstructorID: "bbb", Instructor1: "Brown, Bo", B: "10"},
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24s", InstructorID: "bbb", Instructor1: "Brown, Bo", B: "10"},
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "003", Term: "24f", InstructorID: "ccc", Instructor1: "Cruz, Cy", C: "12"},
+		},
+		Professors: []types.Professor{
+			{InstructorID: "aaa", NormalizedCoursebookName: "ann adams", QualityRating: 3, DifficultyRating: 4, RatingsCount: 10},
+			{InstructorID: "bbb", NormalizedCoursebookName: "bo brown", QualityRating: 4.5, DifficultyRating: 2, RatingsCount: 20},
+		},
+	})
+
+	tests := []struct {
+		query     string
+		wantOrder string
+		want      []string
+	}{
+		{"", "desc", []string{"aaa", "bbb", "ccc"}},
+		{"?sort=gpa&order=asc", "asc", []string{"ccc", "bbb", "aaa"}},
+		// Instructors without ratings sort last in either order.
+		{"?sort=quality", "desc", []string{"bbb", "aaa", "ccc"}},
+		{"?sort=quality&order=asc", "asc", []string{"aaa", "bbb", "ccc"}},
+		{"?sort=difficulty", "asc", []string{"bbb", "aaa", "ccc"}},
+		{"?sort=difficulty&order=desc", "desc", []string{"aaa", "bbb", "ccc"}},
+		{"?sort=SECTIONS", "desc", []string{"bbb", "ccc", "aaa"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			code, body := get(t, r, "/api/v1/courses/CS/3345/professors"+tt.query, testKey)
+			if code != http.StatusOK {
+				t.Fatalf("status = %d %v, want 200", code, body)
+			}
+			if body["course_prefix"] != "cs" || body["order"] != tt.wantOrder {
+				t.Errorf("course_prefix = %v, order = %v; want cs and %s", body["course_prefix"], body["order"], tt.wantOrder)
+			}
+			var got []string
+			for _, professor := range body["professors"].([]any) {
+				got = append(got, professor.(map[string]any)["instructor_id"].(string))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("professors = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for path, want := range map[string]int{
+		"/api/v1/courses/cs/3345/professors?sort=rank": http.StatusBadRequest,
+		"/api/v1/courses/cs/3345/professors?order=up":  http.StatusBadRequest,
+		"/api/v1/courses/cs/9999/professors":           http.StatusNotFound,
+	} {
+		if code, body := get(t, r, path, testKey); code != want {
+			t.Errorf("GET %s = %d %v, want %d", path, code, body, want)
+		}
+	}
+}
+
+func TestMixedCaseInstructorIDs(t *testing.T) {
+	// Coursebook, the grade files, and the professor data capitalize the same
+	// instructor ID differently.
+	r := newRouterWith(t, &storage.Fixtures{
+		Courses: []types.Course{
+			{SectionAddress: "cs3345.001.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f",
+				InstructorIDs: "JXD123456", Days: "Monday", Times: "10:00 - 11:15"},
+		},
+		Grades: []types.Grades{
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24s", InstructorID: "Jxd123456", A: "5"},
+		},
+		Professors: []types.Professor{
+			{InstructorID: "JXD123456", NormalizedCoursebookName: "jane doe", RatingsCount: 3},
+		},
+	})
+
+	counts := map[string]float64{
+		"/api/v1/courses/24f?instructor_id=jXd123456":             1,
+		"/api/v1/courses/24f?instructor_id=jXd123456&days=monday": 1,
+		"/api/v1/professors/id/jxd123456/sections/24f":            1,
+		"/api/v1/grades/prof/id/jxd123456":                        1,
+		"/api/v1/professors/id/jxd123456/profile?term=24f":        1,
+	}
+	for path, want := range counts {
+		code, body := get(t, r, path, testKey)
+		count := body["count"]
+		if count == nil {
+			count = body["section_count"]
+		}
+		if code != http.StatusOK || count != want {
+			t.Errorf("GET %s = %d with count %v, want 200 with %v", path, code, count, want)
+		}
+	}
+
+	code, body := get(t, r, "/api/v1/professors/id/jxd123456/profile?term=24f", testKey)
+	if grades, _ := body["grades"].(map[string]any); code != http.StatusOK || grades["sections"] != 1.0 {
+		t.Errorf("profile grades = %d %v, want one graded section", code, body["grades"])
+	}
+
+	code, body = get(t, r, "/api/v1/courses/cs/3345/professors", testKey)
+	professors, _ := body["professors"].([]any)
+	if code != http.StatusOK || len(professors) != 1 || professors[0].(map[string]any)["has_profile"] != true {
+		t.Errorf("course professors = %d %v, want one instructor with a profile", code, body["professors"])
+	}
+}
@@ -9,23 +9,24 @@ import (
 	"strconv"
 	"time"
 
-	fb "firebase.google.com/go/v4"
-	"github.com/acmutd/acmutd-api/internal/firebase"
+	"github.com/acmutd/acmutd-api/internal/search"
 	"github.com/acmutd/acmutd-api/internal/server/handlers"
 	"github.com/acmutd/acmutd-api/internal/server/middleware"
 	"github.com/acmutd/acmutd-api/internal/server/ratelimit"
 	"github.com/acmutd/acmutd-api/internal/server/router"
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/backend"
 	"github.com/patrickmn/go-cache"
-	"google.golang.org/api/option"
 )
 
 const (
 	apiKeyCacheTTL    = 5 * time.Minute
 	rateLimitCacheTTL = 1 * time.Minute
+	searchIndexTTL    = 15 * time.Minute
 )
 
 type Server struct {
-	db          *firebase.Firestore
+	db          storage.Store
 	apiKeyCache *cache.Cache
 	rateLimiter *ratelimit.Limiter
 	port        int
@@ -39,22 +40,9 @@ func NewServer() *http.Server {
 	}
 	log.Printf("[acmutd-api] Starting server on port %d", port)
 
-	var configPath string
-	if os.Getenv("SAVE_ENVIRONMENT") == "prod" {
-		configPath = "prod." + os.Getenv("FB_CONFIG")
-	} else {
-		configPath = "dev." + os.Getenv("FB_CONFIG")
-	}
-
-	sa := option.WithCredentialsFile(configPath)
-	app, err := fb.NewApp(context.Background(), nil, sa)
-	if err != nil {
-		log.Fatalf("error initializing firebase app: %v\n", err)
-	}
-
-	db, err := firebase.NewFirestore(context.Background(), app)
+	db, err := backend.Open(context.Background())
 	if err != nil {
-		log.Fatalf("error initializing firestore: %v\n", err)
+		log.Fatalf("error initializing storage backend: %v\n", err)
 	}
 
 	// Delete all existing admin keys and generate a new one
@@ -64,7 +52,7 @@ func NewServer() *http.Server {
 		log.Printf("[acmutd-api] Warning: failed to delete existing admin keys: %v", err)
 	}
 
-	// Generate a new admin key in memory and store it in Firebase
+	// Generate a new admin key in memory and store it in the storage backend
 	adminKey, err := db.GenerateAdminAPIKey(ctx)
 	if err != nil {
 		log.Fatalf("failed to generate admin key: %v", err)
@@ -83,7 +71,7 @@ func NewServer() *http.Server {
 		adminKey:    adminKey,
 	}
 
-	handler := handlers.New(newServer.db)
+	handler := handlers.New(newServer.db, search.NewService(newServer.db, searchIndexTTL))
 	middlewares := middleware.NewManager(newServer.db, newServer.apiKeyCache, newServer.rateLimiter, newServer.adminKey)
 	httpHandler := router.New(handler, middlewares)
 
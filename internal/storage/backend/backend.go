@@ -0,0 +1,116 @@
+// Package backend selects and opens the storage.Store configured for the process.
+package backend
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	fb "firebase.google.com/go/v4"
+	"github.com/acmutd/acmutd-api/internal/firebase"
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/storage/memory"
+	"github.com/acmutd/acmutd-api/internal/storage/sqlite"
+	"google.golang.org/api/option"
+)
+
+const (
+	Firestore = "firestore"
+	SQLite    = "sqlite"
+	Memory    = "memory"
+
+	defaultSQLitePath = "acmutd.db"
+)
+
+// Name returns the configured backend from STORAGE_BACKEND, defaulting to Firestore.
+func Name() string {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE_BACKEND")))
+	if name == "" {
+		return Firestore
+	}
+	return name
+}
+
+/*
+Open builds the backend selected by STORAGE_BACKEND:
+
+  - firestore (default): uses {dev|prod}.{FB_CONFIG} depending on SAVE_ENVIRONMENT
+  - sqlite: opens the file at SQLITE_PATH (default acmutd.db)
+  - memory: starts empty
+
+For sqlite and memory, STORAGE_FIXTURES may point at a JSON fixtures file
+(see storage.Fixtures) that is loaded on startup.
+*/
+func Open(ctx context.Context) (storage.Store, error) {
+	switch Name() {
+	case Firestore:
+		return OpenFirestore(ctx)
+	case SQLite:
+		path := strings.TrimSpace(os.Getenv("SQLITE_PATH"))
+		if path == "" {
+			path = defaultSQLitePath
+		}
+
+		store, err := sqlite.Open(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		fixtures, err := loadFixtures()
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+		if err := store.Seed(ctx, fixtures); err != nil {
+			store.Close()
+			return nil, fmt.Errorf("failed to seed sqlite database: %w", err)
+		}
+
+		log.Printf("Using sqlite storage at %s", path)
+		return store, nil
+	case Memory:
+		fixtures, err := loadFixtures()
+		if err != nil {
+			return nil, err
+		}
+
+		log.Println("Using in-memory storage")
+		return memory.NewFromFixtures(fixtures), nil
+	default:
+		return nil, fmt.Errorf("invalid STORAGE_BACKEND: %s (must be 'firestore', 'sqlite', or 'memory')", Name())
+	}
+}
+
+// OpenFirestore connects to the Firestore project for the current SAVE_ENVIRONMENT.
+func OpenFirestore(ctx context.Context) (*firebase.Firestore, error) {
+	var configPath string
+	if os.Getenv("SAVE_ENVIRONMENT") == "prod" {
+		configPath = "prod." + os.Getenv("FB_CONFIG")
+	} else {
+		configPath = "dev." + os.Getenv("FB_CONFIG")
+	}
+
+	app, err := fb.NewApp(ctx, nil, option.WithCredentialsFile(configPath))
+	if err != nil {
+		return nil, fmt.Errorf("error initializing firebase app: %w", err)
+	}
+
+	db, err := firebase.NewFirestore(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("error initializing firestore: %w", err)
+	}
+
+	return db, nil
+}
+
+func loadFixtures() (*storage.Fixtures, error) {
+	path := strings.TrimSpace(os.Getenv("STORAGE_FIXTURES"))
+	if path == "" {
+		return nil, nil
+	}
+
+	log.Printf("Loading storage fixtures from %s", path)
+	return storage.LoadFixtures(path)
+}
@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// PreparedCourse is a course normalized for a term along with the document IDs
+// used by the hierarchical courses/{prefix}/numbers/{number}/sections layout.
+type PreparedCourse struct {
+	Course    types.Course
+	PrefixID  string
+	NumberID  string
+	SectionID string
+}
+
+func NormalizeCoursePrefix(prefix string) string {
+	return strings.ToLower(strings.TrimSpace(prefix))
+}
+
+func NormalizeCourseNumber(number string) string {
+	return strings.ToLower(strings.TrimSpace(number))
+}
+
+func NormalizeTerm(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// NormalizeInstructorID lowercases an instructor ID so sections, professors,
+// and grades are found by instructor however the ID was capitalized.
+func NormalizeInstructorID(id string) string {
+	return types.NormalizeInstructorID(id)
+}
+
+// InstructorIDList splits a section's comma-separated instructor IDs into
+// normalized entries.
+func InstructorIDList(ids string) []string {
+	return types.InstructorIDList(ids)
+}
+
+func SanitizeDocID(value string) string {
+	sanitized := strings.TrimSpace(value)
+	sanitized = strings.ReplaceAll(sanitized, "/", "-")
+	sanitized = strings.ReplaceAll(sanitized, " ", "")
+	return sanitized
+}
+
+func ensureSectionDocID(course types.Course, term string) string {
+	if section := SanitizeDocID(course.SectionAddress); section != "" {
+		return strings.ToLower(section)
+	}
+
+	prefix := SanitizeDocID(NormalizeCoursePrefix(course.CoursePrefix))
+	number := SanitizeDocID(NormalizeCourseNumber(course.CourseNumber))
+	section := SanitizeDocID(strings.ToLower(course.Section))
+	if section == "" {
+		section = "000"
+	}
+	normalizedTerm := SanitizeDocID(NormalizeTerm(term))
+
+	generated := fmt.Sprintf("%s%s.%s.%s", prefix, number, section, normalizedTerm)
+	generated = strings.ReplaceAll(generated, "..", ".")
+	generated = strings.Trim(generated, ".")
+
+	return generated
+}
+
+// sectionAddressPattern matches the course code leading a section address
+// such as "cs2305.001.23f".
+var sectionAddressPattern = regexp.MustCompile(`^([a-z]+)([0-9][0-9a-z]*)\.`)
+
+// SectionAddressID returns the section document ID a section address is stored
+// under, or "" for a blank address.
+func SectionAddressID(address string) string {
+	return strings.ToLower(SanitizeDocID(address))
+}
+
+// SectionDocIDs returns the prefix, number, and section document IDs a section
+// address is stored under. It reports false for addresses that do not start
+// with a course code, such as the "utdstab.00124f" addresses coursebook gives
+// sections it lists without one; those can only be found by section_address.
+func SectionDocIDs(address string) (prefixID, numberID, sectionID string, ok bool) {
+	sectionID = SectionAddressID(address)
+	parts := sectionAddressPattern.FindStringSubmatch(sectionID)
+	if parts == nil {
+		return "", "", "", false
+	}
+	return parts[1], parts[2], sectionID, true
+}
+
+// PrepareCourse normalizes a course for the given (already normalized) term.
+// It reports false when the course lacks the identifiers needed to store it.
+func PrepareCourse(course types.Course, normalizedTerm string) (PreparedCourse, bool) {
+	if normalizedTerm == "" {
+		return PreparedCourse{}, false
+	}
+
+	course.Term = normalizedTerm
+	course.CoursePrefix = NormalizeCoursePrefix(course.CoursePrefix)
+	course.CourseNumber = NormalizeCourseNumber(course.CourseNumber)
+	course.Section = strings.ToLower(strings.TrimSpace(course.Section))
+	// Class numbers are looked up by exact match, so stray spaces must not be stored.
+	course.ClassNumber = strings.TrimSpace(course.ClassNumber)
+
+	prefixID := SanitizeDocID(course.CoursePrefix)
+	numberID := SanitizeDocID(course.CourseNumber)
+	if prefixID == "" || numberID == "" {
+		return PreparedCourse{}, false
+	}
+
+	sectionID := ensureSectionDocID(course, normalizedTerm)
+	course.SectionAddress = sectionID
+	course.DeriveScheduleFields()
+	course.InstructorIDList = InstructorIDList(course.InstructorIDs)
+
+	return PreparedCourse{
+		Course:    course,
+		PrefixID:  prefixID,
+		NumberID:  numberID,
+		SectionID: sectionID,
+	}, true
+}
@@ -0,0 +1,25 @@
+package storage
+
+import "testing"
+
+func TestSectionDocIDs(t *testing.T) {
+	tests := []struct {
+		address                 string
+		prefix, number, section string
+		ok                      bool
+	}{
+		{"cs2305.001.23f", "cs", "2305", "cs2305.001.23f", true},
+		{" CS4V98.0W1.24S ", "cs", "4v98", "cs4v98.0w1.24s", true},
+		{"UTDSTAB.00124S", "", "", "utdstab.00124s", false},
+		{"", "", "", "", false},
+	}
+	for _, tt := range tests {
+		prefix, number, section, ok := SectionDocIDs(tt.address)
+		if prefix != tt.prefix || number != tt.number || ok != tt.ok {
+			t.Errorf("SectionDocIDs(%q) = %q, %q, %v; want %q, %q, %v", tt.address, prefix, number, ok, tt.prefix, tt.number, tt.ok)
+		}
+		if got := SectionAddressID(tt.address); got != tt.section || (ok && section != tt.section) {
+			t.Errorf("SectionDocIDs(%q) section ID = %q (SectionAddressID %q), want %q", tt.address, section, got, tt.section)
+		}
+	}
+}
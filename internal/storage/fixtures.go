@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// Fixtures is the JSON document used to seed non-Firestore backends.
+//
+//	{
+//	  "courses":    [types.Course, ...],
+//	  "grades":     [types.Grades, ...],
+//	  "professors": [types.Professor, ...],
+//	  "api_keys":   [types.APIKey, ...]
+//	}
+//
+// Courses must carry their term; sections without one are skipped on seed.
+type Fixtures struct {
+	Courses    []types.Course    `json:"courses"`
+	Grades     []types.Grades    `json:"grades"`
+	Professors []types.Professor `json:"professors"`
+	APIKeys    []types.APIKey    `json:"api_keys"`
+}
+
+// LoadFixtures reads and decodes a fixtures file from disk.
+func LoadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures %s: %w", path, err)
+	}
+
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to decode fixtures %s: %w", path, err)
+	}
+
+	return &fixtures, nil
+}
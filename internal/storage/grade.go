@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// PreparedGrade is a grade record normalized along with the document IDs used by
+// the grades/{prefix}/courses/{number}/records layout.
+type PreparedGrade struct {
+	Grade    types.Grades
+	PrefixID string
+	NumberID string
+	RecordID string
+}
+
+// PrepareGrade normalizes a grade record and derives its deterministic record ID,
+// {prefix}{number}.{section}.{term}, so re-loading the same data overwrites it.
+// It reports false when the record lacks a prefix, number, or term.
+func PrepareGrade(grade types.Grades) (PreparedGrade, bool) {
+	grade.CoursePrefix = NormalizeCoursePrefix(grade.CoursePrefix)
+	grade.CourseNumber = NormalizeCourseNumber(grade.CourseNumber)
+	grade.Term = NormalizeTerm(grade.Term)
+	grade.Section = strings.ToLower(strings.TrimSpace(grade.Section))
+	grade.InstructorID = NormalizeInstructorID(grade.InstructorID)
+
+	prefixID := SanitizeDocID(grade.CoursePrefix)
+	numberID := SanitizeDocID(grade.CourseNumber)
+	term := SanitizeDocID(grade.Term)
+	if prefixID == "" || numberID == "" || term == "" {
+		return PreparedGrade{}, false
+	}
+
+	section := SanitizeDocID(grade.Section)
+	if section == "" {
+		section = "000"
+	}
+
+	return PreparedGrade{
+		Grade:    grade,
+		PrefixID: prefixID,
+		NumberID: numberID,
+		RecordID: fmt.Sprintf("%s%s.%s.%s", prefixID, numberID, section, term),
+	}, true
+}
@@ -0,0 +1,590 @@
+// Package memory provides an in-process implementation of storage.Store.
+//
+// It mirrors the Firestore backend's query semantics closely enough to run the
+// full HTTP router without Google credentials, and can be seeded from JSON
+// fixtures via storage.LoadFixtures.
+package memory
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+type Store struct {
+	mu         sync.RWMutex
+	courses    map[string]storage.PreparedCourse // keyed by section ID
+	terms      map[string]struct{}
+	grades     map[string]storage.PreparedGrade // keyed by record ID
+	professors map[string]types.Professor       // keyed by instructor ID
+	apiKeys    map[string]types.APIKey
+}
+
+var (
+	_ storage.Store  = (*Store)(nil)
+	_ storage.Writer = (*Store)(nil)
+)
+
+func New() *Store {
+	return &Store{
+		courses:    make(map[string]storage.PreparedCourse),
+		terms:      make(map[string]struct{}),
+		grades:     make(map[string]storage.PreparedGrade),
+		professors: make(map[string]types.Professor),
+		apiKeys:    make(map[string]types.APIKey),
+	}
+}
+
+// NewFromFixtures builds a store seeded with the given fixtures.
+func NewFromFixtures(fixtures *storage.Fixtures) *Store {
+	store := New()
+	store.Seed(fixtures)
+	return store
+}
+
+// Seed loads fixture data into the store, replacing documents with the same ID.
+func (s *Store) Seed(fixtures *storage.Fixtures) {
+	if fixtures == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, course := range fixtures.Courses {
+		s.insertCourseLocked(course, course.Term)
+	}
+	for _, grade := range fixtures.Grades {
+		s.upsertGradeLocked(grade)
+	}
+	for _, professor := range fixtures.Professors {
+		if professor.InstructorID == "" {
+			continue
+		}
+		s.professors[storage.NormalizeInstructorID(professor.InstructorID)] = professor
+	}
+	for _, apiKey := range fixtures.APIKeys {
+		if apiKey.Key == "" {
+			continue
+		}
+		s.apiKeys[apiKey.Key] = apiKey
+	}
+}
+
+func (s *Store) insertCourseLocked(course types.Course, term string) bool {
+	normalizedTerm := storage.NormalizeTerm(term)
+	prepared, ok := storage.PrepareCourse(course, normalizedTerm)
+	if !ok {
+		return false
+	}
+
+	s.terms[normalizedTerm] = struct{}{}
+	s.courses[prepared.SectionID] = prepared
+	return true
+}
+
+func (s *Store) InsertClassesWithIndexes(ctx context.Context, courses []types.Course, term string) (int, error) {
+	if storage.NormalizeTerm(term) == "" {
+		return 0, fmt.Errorf("term is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	written := 0
+	for _, course := range courses {
+		if s.insertCourseLocked(course, term) {
+			written++
+		}
+	}
+	return written, nil
+}
+
+func (s *Store) upsertGradeLocked(grade types.Grades) bool {
+	prepared, ok := storage.PrepareGrade(grade)
+	if !ok {
+		return false
+	}
+
+	s.grades[prepared.RecordID] = prepared
+	return true
+}
+
+func (s *Store) UpsertGrades(ctx context.Context, grades []types.Grades) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	written := 0
+	for _, grade := range grades {
+		if s.upsertGradeLocked(grade) {
+			written++
+		}
+	}
+	return written, nil
+}
+
+// sortedCourses returns matching sections in Firestore document path order.
+func (s *Store) sortedCourses(match func(types.Course) bool) []storage.PreparedCourse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var prepared []storage.PreparedCourse
+	for _, course := range s.courses {
+		if match(course.Course) {
+			prepared = append(prepared, course)
+		}
+	}
+
+	sort.Slice(prepared, func(i, j int) bool {
+		a, b := prepared[i], prepared[j]
+		if a.PrefixID != b.PrefixID {
+			return a.PrefixID < b.PrefixID
+		}
+		if a.NumberID != b.NumberID {
+			return a.NumberID < b.NumberID
+		}
+		return a.SectionID < b.SectionID
+	})
+
+	return prepared
+}
+
+func courseKey(course storage.PreparedCourse) []string {
+	return []string{course.PrefixID, course.NumberID, course.SectionID}
+}
+
+func coursesOf(prepared []storage.PreparedCourse) []types.Course {
+	courses := make([]types.Course, 0, len(prepared))
+	for _, course := range prepared {
+		courses = append(courses, course.Course)
+	}
+	return courses
+}
+
+// pageCourses pages sorted sections and unwraps them.
+func pageCourses(prepared []storage.PreparedCourse, page storage.Page) ([]types.Course, string, error) {
+	window, next, err := storage.PageSorted(prepared, page, storage.CourseCursor, courseKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return coursesOf(window), next, nil
+}
+
+func (s *Store) QueryAllTerms(ctx context.Context, page storage.Page) ([]string, string, error) {
+	s.mu.RLock()
+	terms := make([]string, 0, len(s.terms))
+	for term := range s.terms {
+		terms = append(terms, term)
+	}
+	s.mu.RUnlock()
+
+	return storage.PageTerms(terms, page)
+}
+
+func (s *Store) QueryByCourseNumber(ctx context.Context, term, coursePrefix, courseNumber string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	coursePrefix = storage.NormalizeCoursePrefix(coursePrefix)
+	courseNumber = storage.NormalizeCourseNumber(courseNumber)
+	if term == "" || coursePrefix == "" || courseNumber == "" {
+		return []types.Course{}, "", nil
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term && course.CoursePrefix == coursePrefix && course.CourseNumber == courseNumber
+	})
+
+	return pageCourses(courses, page)
+}
+
+func (s *Store) QueryByCoursePrefix(ctx context.Context, term, coursePrefix string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	coursePrefix = storage.NormalizeCoursePrefix(coursePrefix)
+	if term == "" || coursePrefix == "" {
+		return []types.Course{}, "", nil
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term && course.CoursePrefix == coursePrefix
+	})
+
+	return pageCourses(courses, page)
+}
+
+func (s *Store) GetAllCoursesByTerm(ctx context.Context, term string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	if term == "" {
+		return []types.Course{}, "", nil
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term
+	})
+
+	return pageCourses(courses, page)
+}
+
+func (s *Store) QueryBySchool(ctx context.Context, term, school string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	school = strings.TrimSpace(school)
+	if term == "" || school == "" {
+		return []types.Course{}, "", nil
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term && string(course.School) == school
+	})
+
+	return pageCourses(courses, page)
+}
+
+func (s *Store) GetCoursesByAddress(ctx context.Context, addresses []string) ([]types.Course, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	courses := []types.Course{}
+	for _, address := range addresses {
+		if prepared, ok := s.courses[storage.SectionAddressID(address)]; ok {
+			courses = append(courses, prepared.Course)
+		}
+	}
+	return courses, nil
+}
+
+func (s *Store) QueryByInstructor(ctx context.Context, term, instructorID string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	instructorID = storage.NormalizeInstructorID(instructorID)
+	if term == "" || instructorID == "" {
+		return []types.Course{}, "", nil
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term && slices.Contains(course.InstructorIDList, instructorID)
+	})
+
+	return pageCourses(courses, page)
+}
+
+func (s *Store) GetCourseByClassNumber(ctx context.Context, term, classNumber string) (*types.Course, error) {
+	term = storage.NormalizeTerm(term)
+	classNumber = strings.TrimSpace(classNumber)
+	if term == "" || classNumber == "" {
+		return nil, storage.ErrNotFound
+	}
+
+	courses := s.sortedCourses(func(course types.Course) bool {
+		return course.Term == term && strings.TrimSpace(course.ClassNumber) == classNumber
+	})
+	if len(courses) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return &courses[0].Course, nil
+}
+
+// GetPrefixesByTerm returns the distinct course prefixes offered in a term.
+func (s *Store) GetPrefixesByTerm(ctx context.Context, term string) ([]string, error) {
+	term = storage.NormalizeTerm(term)
+	if term == "" {
+		return nil, nil
+	}
+
+	unique := make(map[string]struct{})
+	for _, course := range s.sortedCourses(func(course types.Course) bool { return course.Term == term }) {
+		if prefix := strings.TrimSpace(course.Course.CoursePrefix); prefix != "" {
+			unique[prefix] = struct{}{}
+		}
+	}
+
+	if len(unique) == 0 {
+		return nil, nil
+	}
+
+	prefixes := make([]string, 0, len(unique))
+	for prefix := range unique {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	return prefixes, nil
+}
+
+func (s *Store) GetProfessorById(ctx context.Context, id string) (*types.Professor, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	professor, ok := s.professors[storage.NormalizeInstructorID(id)]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &professor, nil
+}
+
+func (s *Store) ListProfessors(ctx context.Context) ([]types.Professor, error) {
+	s.mu.RLock()
+	professors := make([]types.Professor, 0, len(s.professors))
+	for _, professor := range s.professors {
+		professors = append(professors, professor)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(professors, func(i, j int) bool {
+		return professors[i].InstructorID < professors[j].InstructorID
+	})
+	return professors, nil
+}
+
+func (s *Store) UpsertProfessors(ctx context.Context, professors []types.Professor) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	written := 0
+	for _, professor := range professors {
+		if professor.InstructorID == "" {
+			continue
+		}
+		s.professors[storage.NormalizeInstructorID(professor.InstructorID)] = professor
+		written++
+	}
+	return written, nil
+}
+
+func (s *Store) DeleteProfessors(ctx context.Context, ids []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for _, id := range ids {
+		id = storage.NormalizeInstructorID(id)
+		if _, ok := s.professors[id]; ok {
+			delete(s.professors, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (s *Store) GetProfessorsByName(ctx context.Context, name string, page storage.Page) ([]types.Professor, string, error) {
+	normalizedName := strings.ToLower(strings.TrimSpace(name))
+	if normalizedName == "" {
+		return []types.Professor{}, "", nil
+	}
+
+	s.mu.RLock()
+	var professors []types.Professor
+	for _, professor := range s.professors {
+		if professor.NormalizedCoursebookName == normalizedName {
+			professors = append(professors, professor)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(professors, func(i, j int) bool {
+		return professors[i].InstructorID < professors[j].InstructorID
+	})
+
+	return storage.PageSorted(professors, page, storage.ProfessorCursor, func(professor types.Professor) []string {
+		return []string{professor.InstructorID}
+	})
+}
+
+func (s *Store) GetProfessorsByRMPID(ctx context.Context, rmpID string) ([]types.Professor, error) {
+	rmpID = strings.TrimSpace(rmpID)
+	if rmpID == "" {
+		return []types.Professor{}, nil
+	}
+
+	s.mu.RLock()
+	professors := []types.Professor{}
+	for _, professor := range s.professors {
+		if professor.RMPID == rmpID {
+			professors = append(professors, professor)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(professors, func(i, j int) bool {
+		return professors[i].InstructorID < professors[j].InstructorID
+	})
+	return professors, nil
+}
+
+// filterGrades returns matching records ordered like the Firestore
+// grades/{prefix}/courses/{number}/records tree.
+func (s *Store) filterGrades(match func(types.Grades) bool) []storage.PreparedGrade {
+	s.mu.RLock()
+	var grades []storage.PreparedGrade
+	for _, grade := range s.grades {
+		if match(grade.Grade) {
+			grades = append(grades, grade)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(grades, func(i, j int) bool {
+		return storage.CompareKeys(gradeKey(grades[i]), gradeKey(grades[j])) < 0
+	})
+
+	return grades
+}
+
+func gradeKey(grade storage.PreparedGrade) []string {
+	return []string{grade.PrefixID, grade.NumberID, grade.RecordID}
+}
+
+// pageGrades pages sorted records and unwraps them.
+func pageGrades(prepared []storage.PreparedGrade, page storage.Page) ([]types.Grades, string, error) {
+	window, next, err := storage.PageSorted(prepared, page, storage.GradeCursor, gradeKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	grades := make([]types.Grades, 0, len(window))
+	for _, grade := range window {
+		grades = append(grades, grade.Grade)
+	}
+	return grades, next, nil
+}
+
+func (s *Store) GetGradesByPrefix(ctx context.Context, prefix string, page storage.Page) ([]types.Grades, string, error) {
+	prefix = storage.NormalizeCoursePrefix(prefix)
+	grades := s.filterGrades(func(grade types.Grades) bool {
+		return grade.CoursePrefix == prefix
+	})
+	return pageGrades(grades, page)
+}
+
+func (s *Store) GetGradesByPrefixAndNumber(ctx context.Context, prefix, number string, page storage.Page) ([]types.Grades, string, error) {
+	prefix = storage.NormalizeCoursePrefix(prefix)
+	number = storage.NormalizeCourseNumber(number)
+	grades := s.filterGrades(func(grade types.Grades) bool {
+		return grade.CoursePrefix == prefix && grade.CourseNumber == number
+	})
+	return pageGrades(grades, page)
+}
+
+func (s *Store) GetGradesByPrefixAndTerm(ctx context.Context, prefix, term string, page storage.Page) ([]types.Grades, string, error) {
+	prefix = storage.NormalizeCoursePrefix(prefix)
+	term = storage.NormalizeTerm(term)
+	grades := s.filterGrades(func(grade types.Grades) bool {
+		return grade.CoursePrefix == prefix && grade.Term == term
+	})
+	return pageGrades(grades, page)
+}
+
+func (s *Store) GetGradesByProfId(ctx context.Context, profId string, page storage.Page) ([]types.Grades, string, error) {
+	profId = storage.NormalizeInstructorID(profId)
+	grades := s.filterGrades(func(grade types.Grades) bool {
+		return grade.InstructorID == profId
+	})
+	return pageGrades(grades, page)
+}
+
+func (s *Store) GetGradesByProfName(ctx context.Context, profName string, page storage.Page) ([]types.Grades, string, error) {
+	grades := s.filterGrades(func(grade types.Grades) bool {
+		return grade.InstructorNameNormalized == profName
+	})
+	return pageGrades(grades, page)
+}
+
+func generateKey() (string, error) {
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(keyBytes), nil
+}
+
+func (s *Store) GenerateAPIKey(ctx context.Context, rateLimit int, windowSeconds int, isAdmin bool, expiresAt time.Time) (string, error) {
+	key, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apiKeys[key] = types.APIKey{
+		Key:           key,
+		RateLimit:     rateLimit,
+		WindowSeconds: windowSeconds,
+		IsAdmin:       isAdmin,
+		CreatedAt:     time.Now(),
+		ExpiresAt:     expiresAt,
+	}
+
+	return key, nil
+}
+
+func (s *Store) ValidateAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	apiKey, ok := s.apiKeys[key]
+	if !ok {
+		return nil, nil
+	}
+	return &apiKey, nil
+}
+
+func (s *Store) UpdateKeyUsage(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	apiKey, ok := s.apiKeys[key]
+	if !ok {
+		return storage.ErrNotFound
+	}
+	apiKey.UsageCount++
+	s.apiKeys[key] = apiKey
+	return nil
+}
+
+func (s *Store) GetAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	apiKey, ok := s.apiKeys[key]
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return &apiKey, nil
+}
+
+func (s *Store) DeleteAllAdminKeys(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, apiKey := range s.apiKeys {
+		if apiKey.IsAdmin {
+			delete(s.apiKeys, key)
+		}
+	}
+	return nil
+}
+
+func (s *Store) GenerateAdminAPIKey(ctx context.Context) (string, error) {
+	baseKey, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+	adminKey := "admin-" + baseKey
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.apiKeys[adminKey] = types.APIKey{
+		Key:       adminKey,
+		IsAdmin:   true,
+		CreatedAt: time.Now(),
+	}
+
+	return adminKey, nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+func newTestStore() *Store {
+	return NewFromFixtures(&storage.Fixtures{
+		Courses: []types.Course{
+			{SectionAddress: "math2413.001.24f", CoursePrefix: "math", CourseNumber: "2413", Section: "001", Term: "24f"},
+			{SectionAddress: "cs3345.002.24f", CoursePrefix: "cs", CourseNumber: "3345", Section: "002", Term: "24f"},
+			{SectionAddress: "cs3345.001.24f", CoursePrefix: "CS", CourseNumber: "3345", Section: "001", Term: "24F"},
+			{SectionAddress: "cs1337.001.24f", CoursePrefix: "cs", CourseNumber: "1337", Section: "001", Term: "24f"},
+			{SectionAddress: "cs1337.001.24s", CoursePrefix: "cs", CourseNumber: "1337", Section: "001", Term: "24s"},
+			{SectionAddress: "utdstab.00124s", CoursePrefix: "cs", CourseNumber: "4v98", Section: "001", Term: "24s"},
+		},
+		Grades: []types.Grades{
+			{CoursePrefix: "cs", CourseNumber: "3345", Section: "001", Term: "24f"},
+			{CoursePrefix: "cs", CourseNumber: "1337", Section: "002", Term: "24f"},
+			{CoursePrefix: "cs", CourseNumber: "1337", Section: "001", Term: "24f"},
+		},
+	})
+}
+
+// collect follows next cursors from the first page to the last.
+func collect[T any](t *testing.T, limit int, query func(storage.Page) ([]T, string, error)) []T {
+	t.Helper()
+
+	var all []T
+	page := storage.Page{Limit: limit}
+	for {
+		items, next, err := query(page)
+		if err != nil {
+			t.Fatalf("query(%+v): %v", page, err)
+		}
+		if len(items) > limit {
+			t.Fatalf("query(%+v) returned %d items, over the limit", page, len(items))
+		}
+		all = append(all, items...)
+		if next == "" {
+			return all
+		}
+		page = storage.Page{Limit: limit, Cursor: next}
+	}
+}
+
+func TestCoursePaging(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore()
+	want := []string{"cs1337.001.24f", "cs3345.001.24f", "cs3345.002.24f", "math2413.001.24f"}
+
+	courses := collect(t, 3, func(page storage.Page) ([]types.Course, string, error) {
+		return s.GetAllCoursesByTerm(ctx, "24f", page)
+	})
+	var got []string
+	for _, course := range courses {
+		got = append(got, course.SectionAddress)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("paging by cursor returned %v, want %v", got, want)
+	}
+
+	courses, next, err := s.GetAllCoursesByTerm(ctx, "24f", storage.Page{Limit: 2, Offset: 2})
+	if err != nil || len(courses) != 2 || courses[0].SectionAddress != want[2] || next != "" {
+		t.Errorf("offset page = %v %q %v, want %v with no next cursor", courses, next, err, want[2:])
+	}
+
+	wrongKind := storage.EncodeCursor(storage.GradeCursor, "cs", "1337", "cs1337.001.24f")
+	if _, _, err := s.GetAllCoursesByTerm(ctx, "24f", storage.Page{Cursor: wrongKind}); !errors.Is(err, storage.ErrInvalidCursor) {
+		t.Errorf("grade cursor on a course listing: err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestGradePaging(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStore()
+
+	grades := collect(t, 1, func(page storage.Page) ([]types.Grades, string, error) {
+		return s.GetGradesByPrefix(ctx, "CS", page)
+	})
+	var got []string
+	for _, grade := range grades {
+		got = append(got, grade.CourseNumber+"."+grade.Section)
+	}
+	if want := []string{"1337.001", "1337.002", "3345.001"}; !slices.Equal(got, want) {
+		t.Errorf("paging by cursor returned %v, want %v", got, want)
+	}
+}
+
+func TestTermPaging(t *testing.T) {
+	s := newTestStore()
+
+	terms := collect(t, 1, func(page storage.Page) ([]string, string, error) {
+		return s.QueryAllTerms(context.Background(), page)
+	})
+	if want := []string{"24s", "24f"}; !slices.Equal(terms, want) {
+		t.Errorf("paging by cursor returned %v, want %v", terms, want)
+	}
+}
+
+func TestGetCoursesByAddress(t *testing.T) {
+	s := newTestStore()
+
+	// Study abroad sections have addresses that do not start with a course code.
+	courses, err := s.GetCoursesByAddress(context.Background(),
+		[]string{"cs3345.001.24f", "UTDSTAB.00124S", "cs9999.001.24f", "cs1337.001.24s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, course := range courses {
+		got = append(got, course.SectionAddress)
+	}
+	if want := []string{"cs3345.001.24f", "utdstab.00124s", "cs1337.001.24s"}; !slices.Equal(got, want) {
+		t.Errorf("GetCoursesByAddress returned %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,148 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// was issued for a different kind of listing.
+var ErrInvalidCursor = errors.New("storage: invalid cursor")
+
+// Page selects a window of an ordered listing. A non-empty Cursor resumes
+// directly after the item it was issued for and takes precedence over Offset,
+// which backends can only honour by skipping documents.
+type Page struct {
+	Limit  int
+	Offset int
+	Cursor string
+}
+
+type cursorPayload struct {
+	Kind string   `json:"k"`
+	Keys []string `json:"v"`
+}
+
+// EncodeCursor packs the sort key of the last item on a page into an opaque token.
+func EncodeCursor(kind string, keys ...string) string {
+	data, _ := json.Marshal(cursorPayload{Kind: kind, Keys: keys})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor unpacks a token produced by EncodeCursor, checking that it was
+// issued for the same kind of listing and carries the expected number of keys.
+func DecodeCursor(cursor, kind string, keyCount int) ([]string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if payload.Kind != kind || len(payload.Keys) != keyCount {
+		return nil, fmt.Errorf("%w: not a %s cursor", ErrInvalidCursor, kind)
+	}
+
+	return payload.Keys, nil
+}
+
+// Cursor kinds shared by every backend.
+const (
+	CourseCursor    = "courses"
+	GradeCursor     = "grades"
+	TermCursor      = "terms"
+	ProfessorCursor = "professors"
+	// FilteredCourseCursor pages course listings filtered in memory, which are
+	// ordered by section address rather than by prefix and number.
+	FilteredCourseCursor = "filtered_courses"
+	// RoomCursor pages room listings computed from a term's sections.
+	RoomCursor = "rooms"
+	// ProfessorDirectoryCursor pages the filtered professor directory. Handlers
+	// append the sort order, so a cursor only resumes the order it came from.
+	ProfessorDirectoryCursor = "professor_directory"
+)
+
+// PageTerms orders term codes chronologically and selects the page window.
+// Backends load every term and page them here because the chronological order
+// ("24s" < "24u" < "24f") cannot be expressed as a plain string sort.
+func PageTerms(terms []string, page Page) ([]string, string, error) {
+	types.SortTermCodes(terms)
+
+	start := page.Offset
+	if page.Cursor != "" {
+		after, err := DecodeCursor(page.Cursor, TermCursor, 1)
+		if err != nil {
+			return nil, "", err
+		}
+		start = len(terms)
+		for i, term := range terms {
+			if types.CompareTermCodes(term, after[0]) > 0 {
+				start = i
+				break
+			}
+		}
+	}
+	if start >= len(terms) {
+		return []string{}, "", nil
+	}
+
+	terms = terms[start:]
+	if page.Limit <= 0 || len(terms) <= page.Limit {
+		return terms, "", nil
+	}
+
+	terms = terms[:page.Limit]
+	return terms, EncodeCursor(TermCursor, terms[len(terms)-1]), nil
+}
+
+// PageSorted selects the page window from items already sorted by key, starting
+// after the cursor when one is given and returning the cursor for the
+// following page. Backends that filter in memory use it in place of a query.
+func PageSorted[T any](items []T, page Page, kind string, key func(T) []string) ([]T, string, error) {
+	return PageSortedFunc(items, page, kind, key, CompareKeys)
+}
+
+// PageSortedFunc is PageSorted for items ordered by compare applied to their
+// keys, for listings whose order is not a plain field-by-field string sort.
+func PageSortedFunc[T any](items []T, page Page, kind string, key func(T) []string, compare func(a, b []string) int) ([]T, string, error) {
+	start := page.Offset
+	if page.Cursor != "" {
+		// key functions return a fixed number of keys, even for the zero value.
+		after, err := DecodeCursor(page.Cursor, kind, len(key(*new(T))))
+		if err != nil {
+			return nil, "", err
+		}
+		start = sort.Search(len(items), func(i int) bool {
+			return compare(key(items[i]), after) > 0
+		})
+	}
+	if start >= len(items) {
+		return []T{}, "", nil
+	}
+
+	items = items[start:]
+	if page.Limit <= 0 || len(items) <= page.Limit {
+		return items, "", nil
+	}
+
+	window := items[:page.Limit]
+	return window, EncodeCursor(kind, key(window[len(window)-1])...), nil
+}
+
+// CompareKeys orders two sort keys of the same length field by field.
+func CompareKeys(a, b []string) int {
+	for i := range a {
+		if c := strings.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := EncodeCursor(CourseCursor, "cs", "3345", "cs3345.001.24f")
+
+	keys, err := DecodeCursor(cursor, CourseCursor, 3)
+	if err != nil {
+		t.Fatalf("DecodeCursor of an encoded cursor: %v", err)
+	}
+	if want := []string{"cs", "3345", "cs3345.001.24f"}; !slices.Equal(keys, want) {
+		t.Errorf("DecodeCursor = %v, want %v", keys, want)
+	}
+
+	tests := []struct {
+		name     string
+		cursor   string
+		kind     string
+		keyCount int
+	}{
+		{"other kind", cursor, GradeCursor, 3},
+		{"wrong key count", cursor, CourseCursor, 1},
+		{"not base64", "!!!", CourseCursor, 3},
+		{"not JSON", "bm90LWpzb24", CourseCursor, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor, tt.kind, tt.keyCount); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("DecodeCursor = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestPageSorted(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	key := func(item string) []string { return []string{item} }
+
+	var got []string
+	page := Page{Limit: 2}
+	for range len(items) {
+		window, next, err := PageSorted(items, page, RoomCursor, key)
+		if err != nil {
+			t.Fatalf("PageSorted(%+v): %v", page, err)
+		}
+		got = append(got, window...)
+		if next == "" {
+			break
+		}
+		page.Cursor = next
+	}
+	if !slices.Equal(got, items) {
+		t.Errorf("paging by cursor returned %v, want %v", got, items)
+	}
+
+	window, next, err := PageSorted(items, Page{Limit: 2, Offset: 3}, RoomCursor, key)
+	if err != nil || !slices.Equal(window, []string{"d", "e"}) || next != "" {
+		t.Errorf("offset page = %v %q %v, want [d e] with no next cursor", window, next, err)
+	}
+
+	// A cursor for an item that has since been removed resumes at its successor.
+	window, _, err = PageSorted([]string{"a", "c"}, Page{Cursor: EncodeCursor(RoomCursor, "b")}, RoomCursor, key)
+	if err != nil || !slices.Equal(window, []string{"c"}) {
+		t.Errorf("page after removed item = %v %v, want [c]", window, err)
+	}
+
+	if _, _, err := PageSorted(items, Page{Cursor: EncodeCursor(TermCursor, "a")}, RoomCursor, key); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("cursor of another kind: err = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestPageSortedFunc(t *testing.T) {
+	items := []string{"c", "b", "a"}
+	key := func(item string) []string { return []string{item} }
+	descending := func(a, b []string) int { return strings.Compare(b[0], a[0]) }
+
+	window, next, err := PageSortedFunc(items, Page{Limit: 1}, RoomCursor, key, descending)
+	if err != nil || !slices.Equal(window, []string{"c"}) {
+		t.Fatalf("first page = %v %v, want [c]", window, err)
+	}
+	window, _, err = PageSortedFunc(items, Page{Limit: 5, Cursor: next}, RoomCursor, key, descending)
+	if err != nil || !slices.Equal(window, []string{"b", "a"}) {
+		t.Errorf("second page = %v %v, want [b a]", window, err)
+	}
+}
+
+func TestPageTerms(t *testing.T) {
+	terms := []string{"24f", "23f", "24s", "24u"}
+
+	window, next, err := PageTerms(terms, Page{Limit: 2})
+	if err != nil || !slices.Equal(window, []string{"23f", "24s"}) {
+		t.Fatalf("first page = %v %v, want [23f 24s]", window, err)
+	}
+	window, next, err = PageTerms(terms, Page{Limit: 2, Cursor: next})
+	if err != nil || !slices.Equal(window, []string{"24u", "24f"}) || next != "" {
+		t.Errorf("second page = %v %q %v, want [24u 24f] with no next cursor", window, next, err)
+	}
+}
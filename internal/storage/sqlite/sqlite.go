@@ -0,0 +1,801 @@
+// Package sqlite implements storage.Store on top of a single SQLite file.
+//
+// It lets the API serve courses, grades, professors, and API keys without any
+// Google services, which is handy for local development and offline demos.
+// Documents are stored as JSON alongside the columns the queries filter on.
+package sqlite
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/storage"
+	"github.com/acmutd/acmutd-api/internal/types"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS terms (
+	term         TEXT PRIMARY KEY,
+	last_updated TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS courses (
+	section_address TEXT PRIMARY KEY,
+	course_prefix   TEXT NOT NULL,
+	course_number   TEXT NOT NULL,
+	term            TEXT NOT NULL,
+	school          TEXT NOT NULL DEFAULT '',
+	data            TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS courses_term_prefix_number ON courses (term, course_prefix, course_number);
+CREATE INDEX IF NOT EXISTS courses_term_school ON courses (term, school);
+
+CREATE TABLE IF NOT EXISTS grades (
+	record_id                  TEXT PRIMARY KEY,
+	course_prefix              TEXT NOT NULL,
+	course_number              TEXT NOT NULL,
+	term                       TEXT NOT NULL,
+	section                    TEXT NOT NULL,
+	instructor_id              TEXT NOT NULL DEFAULT '',
+	instructor_name_normalized TEXT NOT NULL DEFAULT '',
+	data                       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS grades_prefix_number ON grades (course_prefix, course_number);
+CREATE INDEX IF NOT EXISTS grades_instructor_id ON grades (instructor_id);
+CREATE INDEX IF NOT EXISTS grades_instructor_name ON grades (instructor_name_normalized);
+
+CREATE TABLE IF NOT EXISTS professors (
+	instructor_id              TEXT PRIMARY KEY,
+	normalized_coursebook_name TEXT NOT NULL DEFAULT '',
+	data                       TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS professors_name ON professors (normalized_coursebook_name);
+
+CREATE TABLE IF NOT EXISTS api_keys (
+	key            TEXT PRIMARY KEY,
+	rate_limit     INTEGER NOT NULL,
+	window_seconds INTEGER NOT NULL,
+	is_admin       INTEGER NOT NULL,
+	created_at     TIMESTAMP NOT NULL,
+	expires_at     TIMESTAMP NOT NULL,
+	usage_count    INTEGER NOT NULL DEFAULT 0
+);
+`
+
+// normalizeInstructorIDs lowercases the instructor ID columns of rows written
+// before they were stored normalized.
+const normalizeInstructorIDs = `
+UPDATE grades SET instructor_id = lower(trim(instructor_id)) WHERE instructor_id <> lower(trim(instructor_id));
+UPDATE OR REPLACE professors SET instructor_id = lower(trim(instructor_id)) WHERE instructor_id <> lower(trim(instructor_id));
+`
+
+// keyset lists the ORDER BY columns of a paged listing; cursors carry the
+// values of these columns for the last row of a page.
+type keyset struct {
+	kind    string
+	columns []string
+}
+
+var (
+	courseKeys    = keyset{storage.CourseCursor, []string{"course_prefix", "course_number", "section_address"}}
+	gradeKeys     = keyset{storage.GradeCursor, []string{"course_prefix", "course_number", "record_id"}}
+	professorKeys = keyset{storage.ProfessorCursor, []string{"instructor_id"}}
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+var (
+	_ storage.Store  = (*Store)(nil)
+	_ storage.Writer = (*Store)(nil)
+)
+
+// Open opens (creating if needed) the SQLite database at path and applies the schema.
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database %s: %w", path, err)
+	}
+	// SQLite serializes writers; a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to apply sqlite schema: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, normalizeInstructorIDs); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to normalize instructor IDs: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Seed loads fixture data, replacing rows with the same key.
+func (s *Store) Seed(ctx context.Context, fixtures *storage.Fixtures) error {
+	if fixtures == nil {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin seed transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, course := range fixtures.Courses {
+		if _, err := insertCourse(ctx, tx, course, course.Term); err != nil {
+			return err
+		}
+	}
+	for _, grade := range fixtures.Grades {
+		if _, err := upsertGrade(ctx, tx, grade); err != nil {
+			return err
+		}
+	}
+	for _, professor := range fixtures.Professors {
+		if err := upsertProfessor(ctx, tx, professor); err != nil {
+			return err
+		}
+	}
+	for _, apiKey := range fixtures.APIKeys {
+		if err := insertAPIKey(ctx, tx, apiKey); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertCourse upserts a section and reports whether it had enough identifiers to store.
+func insertCourse(ctx context.Context, tx *sql.Tx, course types.Course, term string) (bool, error) {
+	normalizedTerm := storage.NormalizeTerm(term)
+	prepared, ok := storage.PrepareCourse(course, normalizedTerm)
+	if !ok {
+		return false, nil
+	}
+
+	data, err := json.Marshal(prepared.Course)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode course %s: %w", prepared.SectionID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO terms (term, last_updated) VALUES (?, ?)
+		 ON CONFLICT (term) DO UPDATE SET last_updated = excluded.last_updated`,
+		normalizedTerm, time.Now(),
+	); err != nil {
+		return false, fmt.Errorf("failed to store term %s: %w", normalizedTerm, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO courses (section_address, course_prefix, course_number, term, school, data)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+		prepared.SectionID, prepared.Course.CoursePrefix, prepared.Course.CourseNumber,
+		normalizedTerm, string(prepared.Course.School), string(data),
+	); err != nil {
+		return false, fmt.Errorf("failed to store course %s: %w", prepared.SectionID, err)
+	}
+
+	return true, nil
+}
+
+func (s *Store) InsertClassesWithIndexes(ctx context.Context, courses []types.Course, term string) (int, error) {
+	if storage.NormalizeTerm(term) == "" {
+		return 0, fmt.Errorf("term is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin course transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for _, course := range courses {
+		ok, err := insertCourse(ctx, tx, course, term)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			written++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit courses: %w", err)
+	}
+	return written, nil
+}
+
+// upsertGrade writes a grade record under its deterministic ID and reports
+// whether it had enough identifiers to store.
+func upsertGrade(ctx context.Context, tx *sql.Tx, grade types.Grades) (bool, error) {
+	prepared, ok := storage.PrepareGrade(grade)
+	if !ok {
+		return false, nil
+	}
+
+	data, err := json.Marshal(prepared.Grade)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode grade record %s: %w", prepared.RecordID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO grades (record_id, course_prefix, course_number, term, section, instructor_id, instructor_name_normalized, data)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		prepared.RecordID, prepared.Grade.CoursePrefix, prepared.Grade.CourseNumber, prepared.Grade.Term, prepared.Grade.Section,
+		prepared.Grade.InstructorID, prepared.Grade.InstructorNameNormalized, string(data),
+	); err != nil {
+		return false, fmt.Errorf("failed to store grade record %s: %w", prepared.RecordID, err)
+	}
+
+	return true, nil
+}
+
+func (s *Store) UpsertGrades(ctx context.Context, grades []types.Grades) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin grade transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for _, grade := range grades {
+		ok, err := upsertGrade(ctx, tx, grade)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			written++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit grades: %w", err)
+	}
+	return written, nil
+}
+
+func upsertProfessor(ctx context.Context, tx *sql.Tx, professor types.Professor) error {
+	if professor.InstructorID == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(professor)
+	if err != nil {
+		return fmt.Errorf("failed to encode professor %s: %w", professor.InstructorID, err)
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO professors (instructor_id, normalized_coursebook_name, data) VALUES (?, ?, ?)`,
+		storage.NormalizeInstructorID(professor.InstructorID), professor.NormalizedCoursebookName, string(data),
+	); err != nil {
+		return fmt.Errorf("failed to store professor %s: %w", professor.InstructorID, err)
+	}
+
+	return nil
+}
+
+func (s *Store) UpsertProfessors(ctx context.Context, professors []types.Professor) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin professor transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for _, professor := range professors {
+		if professor.InstructorID == "" {
+			continue
+		}
+		if err := upsertProfessor(ctx, tx, professor); err != nil {
+			return 0, err
+		}
+		written++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit professors: %w", err)
+	}
+	return written, nil
+}
+
+func (s *Store) DeleteProfessors(ctx context.Context, ids []string) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin professor transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted := 0
+	for _, id := range ids {
+		result, err := tx.ExecContext(ctx, "DELETE FROM professors WHERE instructor_id = ?", storage.NormalizeInstructorID(id))
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete professor %s: %w", id, err)
+		}
+		if rows, err := result.RowsAffected(); err == nil {
+			deleted += int(rows)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit professor deletions: %w", err)
+	}
+	return deleted, nil
+}
+
+func insertAPIKey(ctx context.Context, tx *sql.Tx, apiKey types.APIKey) error {
+	if apiKey.Key == "" {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO api_keys (key, rate_limit, window_seconds, is_admin, created_at, expires_at, usage_count)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		apiKey.Key, apiKey.RateLimit, apiKey.WindowSeconds, apiKey.IsAdmin,
+		apiKey.CreatedAt, apiKey.ExpiresAt, apiKey.UsageCount,
+	); err != nil {
+		return fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	return nil
+}
+
+// paginate appends LIMIT/OFFSET clauses, fetching one extra row to detect a next page.
+func paginate(query string, args []any, limit, offset int) (string, []any) {
+	if limit <= 0 {
+		return query, args
+	}
+	return query + " LIMIT ? OFFSET ?", append(args, limit+1, offset)
+}
+
+// queryDocuments runs a query selecting a single JSON data column and decodes each row.
+func queryDocuments[T any](ctx context.Context, db *sql.DB, query string, args []any, limit, offset int) ([]T, bool, error) {
+	query, args = paginate(query, args, limit, offset)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to run query: %w", err)
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, false, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		var item T
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			continue
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	hasNext := false
+	if limit > 0 && len(items) > limit {
+		hasNext = true
+		items = items[:limit]
+	}
+
+	return items, hasNext, nil
+}
+
+// queryPage runs a keyset-ordered query over table, decoding the JSON data
+// column of each row. A cursor resumes after the row it was issued for using a
+// row-value comparison; otherwise page.Offset is applied.
+func queryPage[T any](ctx context.Context, db *sql.DB, table, where string, args []any, keys keyset, page storage.Page) ([]T, string, error) {
+	columns := strings.Join(keys.columns, ", ")
+
+	var conditions []string
+	if where != "" {
+		conditions = append(conditions, where)
+	}
+	if page.Cursor != "" {
+		after, err := storage.DecodeCursor(page.Cursor, keys.kind, len(keys.columns))
+		if err != nil {
+			return nil, "", err
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(after)), ", ")
+		conditions = append(conditions, fmt.Sprintf("(%s) > (%s)", columns, placeholders))
+		for _, key := range after {
+			args = append(args, key)
+		}
+		page.Offset = 0
+	}
+
+	query := fmt.Sprintf("SELECT data, %s FROM %s", columns, table)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query, args = paginate(query+" ORDER BY "+columns, args, page.Limit, page.Offset)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to run query: %w", err)
+	}
+	defer rows.Close()
+
+	items := []T{}
+	var rowKeys [][]string
+	for rows.Next() {
+		var data string
+		values := make([]string, len(keys.columns))
+		dest := []any{&data}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, "", fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		var item T
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			continue
+		}
+		items = append(items, item)
+		rowKeys = append(rowKeys, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to read rows: %w", err)
+	}
+
+	nextCursor := ""
+	if page.Limit > 0 && len(items) > page.Limit {
+		items = items[:page.Limit]
+		nextCursor = storage.EncodeCursor(keys.kind, rowKeys[page.Limit-1]...)
+	}
+
+	return items, nextCursor, nil
+}
+
+func (s *Store) QueryAllTerms(ctx context.Context, page storage.Page) ([]string, string, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT term FROM terms")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query terms: %w", err)
+	}
+	defer rows.Close()
+
+	var terms []string
+	for rows.Next() {
+		var term string
+		if err := rows.Scan(&term); err != nil {
+			return nil, "", fmt.Errorf("failed to get next term: %w", err)
+		}
+		terms = append(terms, term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", fmt.Errorf("failed to read terms: %w", err)
+	}
+
+	return storage.PageTerms(terms, page)
+}
+
+func (s *Store) QueryByCourseNumber(ctx context.Context, term, coursePrefix, courseNumber string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	coursePrefix = storage.NormalizeCoursePrefix(coursePrefix)
+	courseNumber = storage.NormalizeCourseNumber(courseNumber)
+	if term == "" || coursePrefix == "" || courseNumber == "" {
+		return []types.Course{}, "", nil
+	}
+
+	return queryPage[types.Course](ctx, s.db, "courses", "term = ? AND course_prefix = ? AND course_number = ?",
+		[]any{term, coursePrefix, courseNumber}, courseKeys, page)
+}
+
+func (s *Store) QueryByCoursePrefix(ctx context.Context, term, coursePrefix string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	coursePrefix = storage.NormalizeCoursePrefix(coursePrefix)
+	if term == "" || coursePrefix == "" {
+		return []types.Course{}, "", nil
+	}
+
+	return queryPage[types.Course](ctx, s.db, "courses", "term = ? AND course_prefix = ?",
+		[]any{term, coursePrefix}, courseKeys, page)
+}
+
+func (s *Store) GetAllCoursesByTerm(ctx context.Context, term string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	if term == "" {
+		return []types.Course{}, "", nil
+	}
+
+	return queryPage[types.Course](ctx, s.db, "courses", "term = ?",
+		[]any{term}, courseKeys, page)
+}
+
+func (s *Store) QueryBySchool(ctx context.Context, term, school string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	school = strings.TrimSpace(school)
+	if term == "" || school == "" {
+		return []types.Course{}, "", nil
+	}
+
+	return queryPage[types.Course](ctx, s.db, "courses", "term = ? AND school = ?",
+		[]any{term, school}, courseKeys, page)
+}
+
+func (s *Store) GetCoursesByAddress(ctx context.Context, addresses []string) ([]types.Course, error) {
+	var sectionIDs []string
+	var args []any
+	for _, address := range addresses {
+		if sectionID := storage.SectionAddressID(address); sectionID != "" {
+			sectionIDs = append(sectionIDs, sectionID)
+			args = append(args, sectionID)
+		}
+	}
+	if len(sectionIDs) == 0 {
+		return []types.Course{}, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	found, _, err := queryDocuments[types.Course](ctx, s.db,
+		"SELECT data FROM courses WHERE section_address IN ("+placeholders+")", args, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	byAddress := make(map[string]types.Course, len(found))
+	for _, course := range found {
+		byAddress[course.SectionAddress] = course
+	}
+
+	courses := []types.Course{}
+	for _, sectionID := range sectionIDs {
+		if course, ok := byAddress[sectionID]; ok {
+			courses = append(courses, course)
+		}
+	}
+	return courses, nil
+}
+
+// QueryByInstructor lists a term's sections taught by an instructor, matching
+// against the instructor_id_list array of the stored document.
+func (s *Store) QueryByInstructor(ctx context.Context, term, instructorID string, page storage.Page) ([]types.Course, string, error) {
+	term = storage.NormalizeTerm(term)
+	instructorID = storage.NormalizeInstructorID(instructorID)
+	if term == "" || instructorID == "" {
+		return []types.Course{}, "", nil
+	}
+
+	return queryPage[types.Course](ctx, s.db, "courses",
+		"term = ? AND EXISTS (SELECT 1 FROM json_each(data, '$.instructor_id_list') WHERE json_each.value = ?)",
+		[]any{term, instructorID}, courseKeys, page)
+}
+
+// GetCourseByClassNumber finds a term's section by class number. The class
+// number is read from the stored document, so no column or migration is needed.
+func (s *Store) GetCourseByClassNumber(ctx context.Context, term, classNumber string) (*types.Course, error) {
+	term = storage.NormalizeTerm(term)
+	classNumber = strings.TrimSpace(classNumber)
+	if term == "" || classNumber == "" {
+		return nil, storage.ErrNotFound
+	}
+
+	courses, _, err := queryDocuments[types.Course](ctx, s.db,
+		"SELECT data FROM courses WHERE term = ? AND trim(json_extract(data, '$.class_number')) = ? ORDER BY section_address",
+		[]any{term, classNumber}, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(courses) == 0 {
+		return nil, storage.ErrNotFound
+	}
+	return &courses[0], nil
+}
+
+// GetPrefixesByTerm returns the distinct course prefixes offered in a term.
+func (s *Store) GetPrefixesByTerm(ctx context.Context, term string) ([]string, error) {
+	term = storage.NormalizeTerm(term)
+	if term == "" {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT DISTINCT course_prefix FROM courses WHERE term = ? AND course_prefix != '' ORDER BY course_prefix", term)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prefixes: %w", err)
+	}
+	defer rows.Close()
+
+	var prefixes []string
+	for rows.Next() {
+		var prefix string
+		if err := rows.Scan(&prefix); err != nil {
+			return nil, fmt.Errorf("failed to iterate prefixes: %w", err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	return prefixes, rows.Err()
+}
+
+func (s *Store) GetProfessorById(ctx context.Context, id string) (*types.Professor, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, "SELECT data FROM professors WHERE instructor_id = ?",
+		storage.NormalizeInstructorID(id)).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var professor types.Professor
+	if err := json.Unmarshal([]byte(data), &professor); err != nil {
+		return nil, err
+	}
+
+	return &professor, nil
+}
+
+func (s *Store) ListProfessors(ctx context.Context) ([]types.Professor, error) {
+	professors, _, err := queryDocuments[types.Professor](ctx, s.db,
+		"SELECT data FROM professors ORDER BY instructor_id", nil, 0, 0)
+	return professors, err
+}
+
+func (s *Store) GetProfessorsByName(ctx context.Context, name string, page storage.Page) ([]types.Professor, string, error) {
+	normalizedName := strings.ToLower(strings.TrimSpace(name))
+	if normalizedName == "" {
+		return []types.Professor{}, "", nil
+	}
+
+	return queryPage[types.Professor](ctx, s.db, "professors", "normalized_coursebook_name = ?",
+		[]any{normalizedName}, professorKeys, page)
+}
+
+func (s *Store) GetProfessorsByRMPID(ctx context.Context, rmpID string) ([]types.Professor, error) {
+	rmpID = strings.TrimSpace(rmpID)
+	if rmpID == "" {
+		return []types.Professor{}, nil
+	}
+
+	professors, _, err := queryDocuments[types.Professor](ctx, s.db,
+		"SELECT data FROM professors WHERE json_extract(data, '$.rmp_id') = ? ORDER BY instructor_id",
+		[]any{rmpID}, 0, 0)
+	return professors, err
+}
+
+func (s *Store) GetGradesByPrefix(ctx context.Context, prefix string, page storage.Page) ([]types.Grades, string, error) {
+	return queryPage[types.Grades](ctx, s.db, "grades", "course_prefix = ?",
+		[]any{storage.NormalizeCoursePrefix(prefix)}, gradeKeys, page)
+}
+
+func (s *Store) GetGradesByPrefixAndNumber(ctx context.Context, prefix, number string, page storage.Page) ([]types.Grades, string, error) {
+	return queryPage[types.Grades](ctx, s.db, "grades", "course_prefix = ? AND course_number = ?",
+		[]any{storage.NormalizeCoursePrefix(prefix), storage.NormalizeCourseNumber(number)}, gradeKeys, page)
+}
+
+func (s *Store) GetGradesByPrefixAndTerm(ctx context.Context, prefix, term string, page storage.Page) ([]types.Grades, string, error) {
+	return queryPage[types.Grades](ctx, s.db, "grades", "course_prefix = ? AND term = ?",
+		[]any{storage.NormalizeCoursePrefix(prefix), storage.NormalizeTerm(term)}, gradeKeys, page)
+}
+
+func (s *Store) GetGradesByProfId(ctx context.Context, profId string, page storage.Page) ([]types.Grades, string, error) {
+	return queryPage[types.Grades](ctx, s.db, "grades", "instructor_id = ?",
+		[]any{storage.NormalizeInstructorID(profId)}, gradeKeys, page)
+}
+
+func (s *Store) GetGradesByProfName(ctx context.Context, profName string, page storage.Page) ([]types.Grades, string, error) {
+	return queryPage[types.Grades](ctx, s.db, "grades", "instructor_name_normalized = ?",
+		[]any{profName}, gradeKeys, page)
+}
+
+func generateKey() (string, error) {
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	return hex.EncodeToString(keyBytes), nil
+}
+
+func (s *Store) storeAPIKey(ctx context.Context, apiKey types.APIKey) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertAPIKey(ctx, tx, apiKey); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Store) GenerateAPIKey(ctx context.Context, rateLimit int, windowSeconds int, isAdmin bool, expiresAt time.Time) (string, error) {
+	key, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+
+	apiKey := types.APIKey{
+		Key:           key,
+		RateLimit:     rateLimit,
+		WindowSeconds: windowSeconds,
+		IsAdmin:       isAdmin,
+		CreatedAt:     time.Now(),
+		ExpiresAt:     expiresAt,
+	}
+
+	return key, s.storeAPIKey(ctx, apiKey)
+}
+
+func (s *Store) getAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
+	var apiKey types.APIKey
+	err := s.db.QueryRowContext(ctx,
+		`SELECT key, rate_limit, window_seconds, is_admin, created_at, expires_at, usage_count
+		 FROM api_keys WHERE key = ?`, key,
+	).Scan(&apiKey.Key, &apiKey.RateLimit, &apiKey.WindowSeconds, &apiKey.IsAdmin,
+		&apiKey.CreatedAt, &apiKey.ExpiresAt, &apiKey.UsageCount)
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (s *Store) ValidateAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
+	apiKey, err := s.getAPIKey(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return apiKey, err
+}
+
+func (s *Store) UpdateKeyUsage(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE api_keys SET usage_count = usage_count + 1 WHERE key = ?", key)
+	return err
+}
+
+func (s *Store) GetAPIKey(ctx context.Context, key string) (*types.APIKey, error) {
+	apiKey, err := s.getAPIKey(ctx, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	return apiKey, err
+}
+
+func (s *Store) DeleteAllAdminKeys(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM api_keys WHERE is_admin = 1"); err != nil {
+		return fmt.Errorf("failed to delete admin keys: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) GenerateAdminAPIKey(ctx context.Context) (string, error) {
+	baseKey, err := generateKey()
+	if err != nil {
+		return "", err
+	}
+	adminKey := "admin-" + baseKey
+
+	apiKey := types.APIKey{
+		Key:       adminKey,
+		IsAdmin:   true,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.storeAPIKey(ctx, apiKey); err != nil {
+		return "", fmt.Errorf("failed to store admin key: %w", err)
+	}
+
+	return adminKey, nil
+}
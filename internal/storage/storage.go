@@ -0,0 +1,114 @@
+// Package storage defines the persistence contract shared by every API backend.
+//
+// Handlers and middleware depend only on these interfaces, so the same router
+// can run against Firestore in production or an in-memory store in tests.
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/acmutd/acmutd-api/internal/types"
+)
+
+// ErrNotFound is returned when a single-document lookup has no match.
+var ErrNotFound = errors.New("storage: not found")
+
+// CourseStore covers the course section and term queries.
+//
+// Paged listings return the items in the window selected by page along with
+// the cursor for the following page, which is empty on the last page.
+type CourseStore interface {
+	QueryAllTerms(ctx context.Context, page Page) ([]string, string, error)
+	QueryByCourseNumber(ctx context.Context, term, coursePrefix, courseNumber string, page Page) ([]types.Course, string, error)
+	QueryByCoursePrefix(ctx context.Context, term, coursePrefix string, page Page) ([]types.Course, string, error)
+	GetAllCoursesByTerm(ctx context.Context, term string, page Page) ([]types.Course, string, error)
+	QueryBySchool(ctx context.Context, term, school string, page Page) ([]types.Course, string, error)
+	// QueryByInstructor lists a term's sections whose instructor IDs include
+	// instructorID.
+	QueryByInstructor(ctx context.Context, term, instructorID string, page Page) ([]types.Course, string, error)
+	// GetCoursesByAddress fetches sections by section address in one round trip,
+	// in the order requested. Addresses with no stored section are left out.
+	GetCoursesByAddress(ctx context.Context, addresses []string) ([]types.Course, error)
+	// GetCourseByClassNumber returns the section of a term with the given class
+	// number, or ErrNotFound.
+	GetCourseByClassNumber(ctx context.Context, term, classNumber string) (*types.Course, error)
+	GetPrefixesByTerm(ctx context.Context, term string) ([]string, error)
+}
+
+// ProfessorStore covers the professors collection.
+type ProfessorStore interface {
+	GetProfessorById(ctx context.Context, id string) (*types.Professor, error)
+	GetProfessorsByName(ctx context.Context, name string, page Page) ([]types.Professor, string, error)
+	// GetProfessorsByRMPID returns the professors matched to a RateMyProfessors
+	// profile, ordered by instructor ID. Several coursebook instructors can be
+	// matched to the same profile.
+	GetProfessorsByRMPID(ctx context.Context, rmpID string) ([]types.Professor, error)
+	ProfessorLister
+}
+
+// ProfessorLister is shared by ProfessorStore, which filters the directory in
+// memory, and ProfessorWriter, which diffs a new load against the collection.
+type ProfessorLister interface {
+	// ListProfessors returns every stored professor.
+	ListProfessors(ctx context.Context) ([]types.Professor, error)
+}
+
+// GradeStore covers the grade distribution records.
+type GradeStore interface {
+	GetGradesByPrefix(ctx context.Context, prefix string, page Page) ([]types.Grades, string, error)
+	GetGradesByPrefixAndNumber(ctx context.Context, prefix, number string, page Page) ([]types.Grades, string, error)
+	GetGradesByPrefixAndTerm(ctx context.Context, prefix, term string, page Page) ([]types.Grades, string, error)
+	GetGradesByProfId(ctx context.Context, profId string, page Page) ([]types.Grades, string, error)
+	GetGradesByProfName(ctx context.Context, profName string, page Page) ([]types.Grades, string, error)
+}
+
+// APIKeyStore covers API key provisioning and validation.
+type APIKeyStore interface {
+	GenerateAPIKey(ctx context.Context, rateLimit int, windowSeconds int, isAdmin bool, expiresAt time.Time) (string, error)
+	// ValidateAPIKey returns a nil key and nil error when the key does not exist.
+	ValidateAPIKey(ctx context.Context, key string) (*types.APIKey, error)
+	UpdateKeyUsage(ctx context.Context, key string) error
+	GetAPIKey(ctx context.Context, key string) (*types.APIKey, error)
+	DeleteAllAdminKeys(ctx context.Context) error
+	GenerateAdminAPIKey(ctx context.Context) (string, error)
+}
+
+// CourseWriter persists scraped course sections.
+type CourseWriter interface {
+	// InsertClassesWithIndexes upserts the sections of a term along with the
+	// term's index documents and returns how many sections were written.
+	// Sections without a prefix or number are skipped.
+	InsertClassesWithIndexes(ctx context.Context, courses []types.Course, term string) (int, error)
+}
+
+// GradeWriter persists grade distribution records.
+type GradeWriter interface {
+	// UpsertGrades writes each record under its deterministic ID (see
+	// PrepareGrade) and returns how many were written. Records without a
+	// prefix, number, or term are skipped.
+	UpsertGrades(ctx context.Context, grades []types.Grades) (int, error)
+}
+
+// ProfessorWriter persists professor profiles keyed by instructor ID.
+type ProfessorWriter interface {
+	ProfessorLister
+	UpsertProfessors(ctx context.Context, professors []types.Professor) (int, error)
+	DeleteProfessors(ctx context.Context, ids []string) (int, error)
+}
+
+// Writer is implemented by backends that can be loaded by the ingest pipeline.
+type Writer interface {
+	CourseWriter
+	GradeWriter
+	ProfessorWriter
+}
+
+// Store is the full set of queries the HTTP layer depends on.
+type Store interface {
+	CourseStore
+	ProfessorStore
+	GradeStore
+	APIKeyStore
+}
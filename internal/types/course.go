@@ -48,7 +48,7 @@ func (s School) String() string {
 //
 // Indexes Required:
 //   - Collection group "sections" with term field (for term-based queries)
-//   - Composite indexes for term+course_prefix, term+course_number queries
+//   - Composite indexes for term+course_prefix, term+course_number, and term+school queries
 //
 // Related Collections:
 //   - terms/{term}/prefixes/{course_prefix} - metadata for available prefixes per term
@@ -91,4 +91,12 @@ type Course struct {
 	// Additional resources
 	Syllabus  string `json:"syllabus" firestore:"syllabus"`   // Syllabus URL or content
 	Textbooks string `json:"textbooks" firestore:"textbooks"` // Required textbooks
+
+	// Structured fields derived from the strings above during ingestion
+	Enrollment Enrollment `json:"enrollment" firestore:"enrollment"` // Parsed enrollment counts
+	Meetings   []Meeting  `json:"meetings" firestore:"meetings"`     // One entry per weekly meeting day
+
+	// InstructorIDList holds the lowercased entries of InstructorIDs so
+	// sections can be queried by instructor with array-contains.
+	InstructorIDList []string `json:"instructor_id_list" firestore:"instructor_id_list"`
 }
@@ -0,0 +1,103 @@
+package types
+
+import "strings"
+
+// CourseV2 is the typed course section returned by the v2 API. List fields
+// that v1 returns as comma-separated strings are split into arrays, and
+// enrollment and meeting times are structured instead of free-form text.
+type CourseV2 struct {
+	SectionAddress string `json:"section_address"`
+	CoursePrefix   string `json:"course_prefix"`
+	CourseNumber   string `json:"course_number"`
+	Section        string `json:"section"`
+	Term           string `json:"term"`
+
+	ClassNumber string `json:"class_number"`
+	Title       string `json:"title"`
+	Topic       string `json:"topic"`
+
+	EnrolledStatus string     `json:"enrolled_status"`
+	Enrollment     Enrollment `json:"enrollment"`
+
+	Instructors   []string `json:"instructors"`
+	InstructorIDs []string `json:"instructor_ids"`
+	Assistants    []string `json:"assistants"`
+
+	Session  string    `json:"session"`
+	Meetings []Meeting `json:"meetings"`
+
+	CoreArea     string `json:"core_area"`
+	ActivityType string `json:"activity_type"`
+	School       School `json:"school"`
+	Dept         string `json:"dept"`
+
+	Syllabus  string `json:"syllabus"`
+	Textbooks string `json:"textbooks"`
+}
+
+// NewCourseV2 converts a stored course into its v2 form. Sections ingested
+// before the structured fields existed are parsed on the fly.
+func NewCourseV2(course Course) CourseV2 {
+	course.EnsureScheduleFields()
+
+	return CourseV2{
+		SectionAddress: course.SectionAddress,
+		CoursePrefix:   course.CoursePrefix,
+		CourseNumber:   course.CourseNumber,
+		Section:        course.Section,
+		Term:           course.Term,
+		ClassNumber:    course.ClassNumber,
+		Title:          course.Title,
+		Topic:          course.Topic,
+		EnrolledStatus: course.EnrolledStatus,
+		Enrollment:     course.Enrollment,
+		Instructors:    SplitList(course.Instructors),
+		InstructorIDs:  SplitList(course.InstructorIDs),
+		Assistants:     SplitList(course.Assistants),
+		Session:        course.Session,
+		Meetings:       course.Meetings,
+		CoreArea:       course.CoreArea,
+		ActivityType:   course.ActivityType,
+		School:         course.School,
+		Dept:           course.Dept,
+		Syllabus:       course.Syllabus,
+		Textbooks:      course.Textbooks,
+	}
+}
+
+// NewCoursesV2 converts a page of stored courses into their v2 form.
+func NewCoursesV2(courses []Course) []CourseV2 {
+	converted := make([]CourseV2, len(courses))
+	for i, course := range courses {
+		converted[i] = NewCourseV2(course)
+	}
+	return converted
+}
+
+// SplitList splits a comma-separated field such as instructors into its
+// trimmed, non-empty entries.
+func SplitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// NormalizeInstructorID lowercases an instructor ID. Coursebook, the grade
+// files, and the professor data do not agree on capitalization.
+func NormalizeInstructorID(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// InstructorIDList splits comma-separated instructor IDs into normalized
+// entries.
+func InstructorIDList(ids string) []string {
+	list := SplitList(ids)
+	for i, id := range list {
+		list[i] = NormalizeInstructorID(id)
+	}
+	return list
+}
@@ -0,0 +1,142 @@
+package types
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// letterGrades are the graded buckets from best to worst with their grade
+// points. NF (failure for non-attendance) counts as an F.
+var letterGrades = []struct {
+	Letter string
+	Points float64
+}{
+	{"A+", 4.0}, {"A", 4.0}, {"A-", 3.67},
+	{"B+", 3.33}, {"B", 3.0}, {"B-", 2.67},
+	{"C+", 2.33}, {"C", 2.0}, {"C-", 1.67},
+	{"D+", 1.33}, {"D", 1.0}, {"D-", 0.67},
+	{"F", 0}, {"NF", 0},
+}
+
+// GradeCounts is the parsed form of a distribution's letter buckets, keyed by
+// bucket name ("A+", "B", "W", ...).
+type GradeCounts map[string]int
+
+// Counts parses every letter bucket of the record. Blank or malformed buckets
+// count as zero.
+func (g Grades) Counts() GradeCounts {
+	buckets := map[string]string{
+		"A+": g.APlus, "A": g.A, "A-": g.AMinus,
+		"B+": g.BPlus, "B": g.B, "B-": g.BMinus,
+		"C+": g.CPlus, "C": g.C, "C-": g.CMinus,
+		"D+": g.DPlus, "D": g.D, "D-": g.DMinus,
+		"F": g.F, "NF": g.NF, "CR": g.CR, "I": g.I, "NC": g.NC, "P": g.P, "W": g.W,
+	}
+	counts := make(GradeCounts, len(buckets))
+	for letter, value := range buckets {
+		counts[letter] = parseGradeCount(value)
+	}
+	return counts
+}
+
+// parseGradeCount parses a bucket count, accepting the float form ("12.0")
+// pandas writes for columns that contain blanks.
+func parseGradeCount(value string) int {
+	if count := parseCount(value); count > 0 {
+		return count
+	}
+	if f, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil && f > 0 {
+		return int(math.Round(f))
+	}
+	return 0
+}
+
+// Add sums other into c.
+func (c GradeCounts) Add(other GradeCounts) {
+	for letter, n := range other {
+		c[letter] += n
+	}
+}
+
+// GradeStats summarizes a grade distribution. Rates are fractions (0-1) of
+// every student in the distribution, including withdrawals and pass/fail
+// grades; the GPA and median only consider letter grades.
+type GradeStats struct {
+	TotalStudents  int     `json:"total_students"`
+	GradedStudents int     `json:"graded_students"` // Students with a letter grade (A+ through F, NF)
+	MeanGPA        float64 `json:"mean_gpa"`        // 0 when no student received a letter grade
+	MedianGrade    string  `json:"median_grade"`    // Empty when no student received a letter grade
+	ARate          float64 `json:"a_rate"`          // A+, A, and A-
+	DFWRate        float64 `json:"dfw_rate"`        // D+ through F, NF, and W
+	WithdrawalRate float64 `json:"withdrawal_rate"`
+}
+
+// Stats computes the summary statistics of the record.
+func (g Grades) Stats() GradeStats {
+	return g.Counts().Stats()
+}
+
+// Stats computes the summary statistics of the counts.
+func (c GradeCounts) Stats() GradeStats {
+	var stats GradeStats
+	for _, n := range c {
+		stats.TotalStudents += n
+	}
+
+	points := 0.0
+	for _, grade := range letterGrades {
+		stats.GradedStudents += c[grade.Letter]
+		points += grade.Points * float64(c[grade.Letter])
+	}
+	if stats.GradedStudents > 0 {
+		stats.MeanGPA = round(points/float64(stats.GradedStudents), 3)
+
+		// The median is the grade of the middle student ranked best to worst,
+		// taking the better of the two middle students when the count is even.
+		middle := (stats.GradedStudents + 1) / 2
+		seen := 0
+		for _, grade := range letterGrades {
+			seen += c[grade.Letter]
+			if seen >= middle {
+				stats.MedianGrade = grade.Letter
+				if grade.Letter == "NF" {
+					stats.MedianGrade = "F"
+				}
+				break
+			}
+		}
+	}
+
+	if stats.TotalStudents > 0 {
+		total := float64(stats.TotalStudents)
+		a := c["A+"] + c["A"] + c["A-"]
+		dfw := c["D+"] + c["D"] + c["D-"] + c["F"] + c["NF"] + c["W"]
+		stats.ARate = round(float64(a)/total, 4)
+		stats.DFWRate = round(float64(dfw)/total, 4)
+		stats.WithdrawalRate = round(float64(c["W"])/total, 4)
+	}
+	return stats
+}
+
+// Shares returns the fraction (0-1) of all students in each bucket.
+func (c GradeCounts) Shares() map[string]float64 {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	shares := make(map[string]float64, len(c))
+	for letter, n := range c {
+		if total > 0 {
+			shares[letter] = round(float64(n)/float64(total), 4)
+		} else {
+			shares[letter] = 0
+		}
+	}
+	return shares
+}
+
+func round(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
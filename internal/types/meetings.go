@@ -0,0 +1,304 @@
+package types
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Enrollment is the parsed form of a section's enrolled_current and enrolled_max strings.
+type Enrollment struct {
+	Current        int `json:"current" firestore:"current"`
+	Max            int `json:"max" firestore:"max"`
+	SeatsRemaining int `json:"seats_remaining" firestore:"seats_remaining"`
+}
+
+// Meeting is one weekly class meeting parsed from a section's days, times, and location.
+// A section meeting Monday and Wednesday has one Meeting per day.
+type Meeting struct {
+	Day          string `json:"day" firestore:"day"`                     // Lowercase weekday name, e.g. "monday"
+	StartMinutes int    `json:"start_minutes" firestore:"start_minutes"` // Minutes after midnight, e.g. 600 for 10:00 AM
+	EndMinutes   int    `json:"end_minutes" firestore:"end_minutes"`
+	Building     string `json:"building" firestore:"building"` // e.g. "ECSS"
+	Room         string `json:"room" firestore:"room"`         // e.g. "2.415"
+}
+
+// Weekday returns the meeting day as a time.Weekday.
+func (m Meeting) Weekday() time.Weekday {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), m.Day) {
+			return day
+		}
+	}
+	return time.Sunday
+}
+
+// ParseEnrollment converts coursebook enrollment counts to integers. Counts
+// that are missing or not numeric are treated as zero.
+func ParseEnrollment(current, max string) Enrollment {
+	enrollment := Enrollment{
+		Current: parseCount(current),
+		Max:     parseCount(max),
+	}
+	if enrollment.Max > enrollment.Current {
+		enrollment.SeatsRemaining = enrollment.Max - enrollment.Current
+	}
+	return enrollment
+}
+
+func parseCount(value string) int {
+	count, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || count < 0 {
+		return 0
+	}
+	return count
+}
+
+// dayNames maps the spellings coursebook and the scrapers use for weekdays.
+var dayNames = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday, "m": time.Monday,
+	"tuesday": time.Tuesday, "tues": time.Tuesday, "tue": time.Tuesday, "tu": time.Tuesday, "t": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "w": time.Wednesday,
+	"thursday": time.Thursday, "thurs": time.Thursday, "thur": time.Thursday, "thu": time.Thursday, "th": time.Thursday, "r": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "f": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sa": time.Saturday, "s": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday, "su": time.Sunday, "u": time.Sunday,
+}
+
+// ParseDays reads a day list such as "Monday, Wednesday", "Tuesday & Thursday",
+// "MWF", or "TTh" into weekdays, in the order given and without repeats.
+func ParseDays(days string) []time.Weekday {
+	var parsed []time.Weekday
+	seen := make(map[time.Weekday]bool)
+	add := func(day time.Weekday) {
+		if !seen[day] {
+			seen[day] = true
+			parsed = append(parsed, day)
+		}
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(days), func(r rune) bool { return !unicode.IsLetter(r) })
+	for _, word := range words {
+		if day, ok := dayNames[word]; ok {
+			add(day)
+			continue
+		}
+		for _, day := range parseCompactDays(word) {
+			add(day)
+		}
+	}
+
+	return parsed
+}
+
+// compactDayCodes are the two-letter codes tried before single letters in
+// compact day lists, so "tth" reads as Tuesday, Thursday.
+var compactDayCodes = []string{"th", "tu", "sa", "su"}
+
+// parseCompactDays reads a run-together day list such as "mwf" or "tth". Words
+// that are not made up entirely of day codes, like "tba", yield no days.
+func parseCompactDays(word string) []time.Weekday {
+	var days []time.Weekday
+	for i := 0; i < len(word); {
+		if i+2 <= len(word) && slices.Contains(compactDayCodes, word[i:i+2]) {
+			days = append(days, dayNames[word[i:i+2]])
+			i += 2
+			continue
+		}
+		day, ok := dayNames[word[i:i+1]]
+		if !ok {
+			return nil
+		}
+		days = append(days, day)
+		i++
+	}
+	return days
+}
+
+// clockPattern matches a clock time with an optional am/pm marker.
+var clockPattern = regexp.MustCompile(`(?i)(\d{1,2}):(\d{2})\s*([ap])?\.?\s*m?\.?`)
+
+// ParseTimeRange reads a range such as "10:00-11:15", "10:00am - 11:15am", or
+// "1:00 PM-2:15 PM" into minutes after midnight. A marker on only the end
+// time carries over to the start unless that would put the start after the end,
+// as in "11:30-12:45pm".
+func ParseTimeRange(times string) (start, end int, ok bool) {
+	matches := clockPattern.FindAllStringSubmatch(times, 2)
+	if len(matches) != 2 {
+		return 0, 0, false
+	}
+
+	endMeridiem := matches[1][3]
+	startMeridiem := matches[0][3]
+	if startMeridiem == "" && endMeridiem != "" {
+		startMeridiem = endMeridiem
+	}
+
+	end, endOK := clockMinutes(matches[1], endMeridiem)
+	start, startOK := clockMinutes(matches[0], startMeridiem)
+	if startOK && endOK && start > end && matches[0][3] == "" && strings.EqualFold(endMeridiem, "p") {
+		start, startOK = clockMinutes(matches[0], "a")
+	}
+	if !startOK || !endOK || start >= end {
+		return 0, 0, false
+	}
+
+	return start, end, true
+}
+
+// hourOnlyPattern matches a whole hour given with an am/pm marker, as in "2pm".
+var hourOnlyPattern = regexp.MustCompile(`(?i)^(\d{1,2})\s*([ap]\.?\s*m?\.?)$`)
+
+// ParseClock reads a single time such as "10:00", "13:30", "1:30pm", or "2pm"
+// into minutes after midnight.
+func ParseClock(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if parts := hourOnlyPattern.FindStringSubmatch(value); parts != nil {
+		value = parts[1] + ":00" + parts[2]
+	}
+	match := clockPattern.FindStringSubmatch(value)
+	if match == nil || strings.TrimSpace(match[0]) != value {
+		return 0, false
+	}
+	return clockMinutes(match, match[3])
+}
+
+// clockMinutes converts a clockPattern match to minutes after midnight, reading
+// the hour as 12-hour when a meridiem is given and 24-hour otherwise.
+func clockMinutes(match []string, meridiem string) (int, bool) {
+	hour, _ := strconv.Atoi(match[1])
+	minute, _ := strconv.Atoi(match[2])
+	if minute > 59 {
+		return 0, false
+	}
+	switch strings.ToLower(meridiem) {
+	case "a":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour %= 12
+	case "p":
+		if hour < 1 || hour > 12 {
+			return 0, false
+		}
+		hour = hour%12 + 12
+	default:
+		if hour > 23 {
+			return 0, false
+		}
+	}
+	return hour*60 + minute, true
+}
+
+// ParseLocation splits a location such as "ECSS 2.415" into building and room.
+// Single-word locations such as "ONLINE" are returned as the building.
+func ParseLocation(location string) (building, room string) {
+	fields := strings.Fields(location)
+	switch len(fields) {
+	case 0:
+		return "", ""
+	case 1:
+		return fields[0], ""
+	default:
+		return fields[0], strings.Join(fields[1:], " ")
+	}
+}
+
+// splitMeetingLines splits a field listing several meeting patterns, one per
+// line or separated by semicolons.
+func splitMeetingLines(value string) []string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(value, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+/*
+ParseMeetings builds the weekly meetings of a section from its free-form days,
+times, and location fields. times24h is preferred and times12h is used when it
+is empty or unparseable.
+
+Sections with several meeting patterns list them one per line (or separated by
+semicolons) in each field; when the fields have the same number of lines they
+are paired up, and otherwise every day shares the first time and location.
+Sections without a parseable day or time, such as online or TBA sections, have
+no meetings.
+*/
+func ParseMeetings(days, times24h, times12h, location string) []Meeting {
+	dayLines := splitMeetingLines(days)
+	timeLines := splitMeetingLines(times24h)
+	if len(timeLines) == 0 || !allParse(timeLines) {
+		timeLines = splitMeetingLines(times12h)
+	}
+	locationLines := splitMeetingLines(location)
+	if len(dayLines) == 0 || len(timeLines) == 0 {
+		return []Meeting{}
+	}
+
+	paired := len(dayLines) == len(timeLines)
+	if !paired {
+		dayLines = []string{strings.Join(dayLines, ", ")}
+		timeLines = timeLines[:1]
+	}
+
+	meetings := []Meeting{}
+	for i, dayLine := range dayLines {
+		start, end, ok := ParseTimeRange(timeLines[i])
+		if !ok {
+			continue
+		}
+
+		var building, room string
+		switch {
+		case paired && len(locationLines) == len(dayLines):
+			building, room = ParseLocation(locationLines[i])
+		case len(locationLines) > 0:
+			building, room = ParseLocation(locationLines[0])
+		}
+
+		for _, day := range ParseDays(dayLine) {
+			meetings = append(meetings, Meeting{
+				Day:          strings.ToLower(day.String()),
+				StartMinutes: start,
+				EndMinutes:   end,
+				Building:     building,
+				Room:         room,
+			})
+		}
+	}
+
+	return meetings
+}
+
+func allParse(timeLines []string) bool {
+	for _, line := range timeLines {
+		if _, _, ok := ParseTimeRange(line); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// DeriveScheduleFields fills in the structured enrollment and meeting fields
+// from the free-form strings coursebook provides.
+func (c *Course) DeriveScheduleFields() {
+	c.Enrollment = ParseEnrollment(c.EnrolledCurrent, c.EnrolledMax)
+	c.Meetings = ParseMeetings(c.Days, c.Times, c.Times12h, c.Location)
+}
+
+// EnsureScheduleFields derives the structured fields, and the instructor ID
+// list, for sections stored before ingestion computed them.
+func (c *Course) EnsureScheduleFields() {
+	if c.Meetings == nil || (c.Enrollment == Enrollment{} && c.EnrolledMax != "") {
+		c.DeriveScheduleFields()
+	}
+	if len(c.InstructorIDList) == 0 && c.InstructorIDs != "" {
+		c.InstructorIDList = InstructorIDList(c.InstructorIDs)
+	}
+}
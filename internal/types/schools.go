@@ -0,0 +1,15 @@
+package types
+
+// SchoolSummary lists a school offering sections in a term along with its
+// departments. It is computed from the sections collection rather than stored.
+type SchoolSummary struct {
+	School       string              `json:"school"`
+	SectionCount int                 `json:"section_count"`
+	Departments  []DepartmentSummary `json:"departments"`
+}
+
+// DepartmentSummary counts the sections a department offers in a term.
+type DepartmentSummary struct {
+	Department   string `json:"department"`
+	SectionCount int    `json:"section_count"`
+}
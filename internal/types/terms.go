@@ -0,0 +1,130 @@
+package types
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Season is the part of the academic year a term falls in.
+type Season string
+
+const (
+	SeasonSpring Season = "spring"
+	SeasonSummer Season = "summer"
+	SeasonFall   Season = "fall"
+)
+
+// seasonCalendar holds each season's position within the year and its
+// approximate first and last day of classes. Exact dates vary by a week or so
+// from year to year; these are close enough to tell which term is in session.
+var seasonCalendar = map[Season]struct {
+	order      int
+	code       string
+	startMonth time.Month
+	startDay   int
+	endMonth   time.Month
+	endDay     int
+}{
+	SeasonSpring: {0, "s", time.January, 15, time.May, 15},
+	SeasonSummer: {1, "u", time.May, 20, time.August, 10},
+	SeasonFall:   {2, "f", time.August, 20, time.December, 15},
+}
+
+// dateLayout is the format of a term's start and end dates.
+const dateLayout = "2006-01-02"
+
+// Term is an academic term parsed from a code like "24f".
+//
+// Codes are a two-digit year followed by a season letter: "s" for spring,
+// "u" for summer, and "f" for fall. Terms order chronologically, so "24s"
+// comes before "24u", which comes before "24f".
+type Term struct {
+	Code      string `json:"code"`       // e.g., "24f"
+	Year      int    `json:"year"`       // e.g., 2024
+	Season    Season `json:"season"`     // "spring", "summer", or "fall"
+	Name      string `json:"name"`       // e.g., "Fall 2024"
+	StartDate string `json:"start_date"` // Approximate first day of classes (YYYY-MM-DD)
+	EndDate   string `json:"end_date"`   // Approximate last day of classes (YYYY-MM-DD)
+}
+
+// ParseTerm parses a term code such as "24f" or "25S".
+func ParseTerm(code string) (Term, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return Term{}, fmt.Errorf("invalid term %q: expected a two-digit year and a season letter", code)
+	}
+
+	year, err := strconv.Atoi(code[:2])
+	if err != nil || code[0] < '0' || code[0] > '9' {
+		return Term{}, fmt.Errorf("invalid term %q: year must be two digits", code)
+	}
+
+	var season Season
+	for candidate, calendar := range seasonCalendar {
+		if calendar.code == code[2:] {
+			season = candidate
+		}
+	}
+	if season == "" {
+		return Term{}, fmt.Errorf("invalid term %q: season must be s, u, or f", code)
+	}
+
+	year += 2000
+	calendar := seasonCalendar[season]
+
+	return Term{
+		Code:      code,
+		Year:      year,
+		Season:    season,
+		Name:      fmt.Sprintf("%s%s %d", strings.ToUpper(string(season[:1])), season[1:], year),
+		StartDate: time.Date(year, calendar.startMonth, calendar.startDay, 0, 0, 0, 0, time.UTC).Format(dateLayout),
+		EndDate:   time.Date(year, calendar.endMonth, calendar.endDay, 0, 0, 0, 0, time.UTC).Format(dateLayout),
+	}, nil
+}
+
+// Compare returns -1, 0, or 1 as t falls before, with, or after other.
+func (t Term) Compare(other Term) int {
+	if t.Year != other.Year {
+		return cmp.Compare(t.Year, other.Year)
+	}
+	return cmp.Compare(seasonCalendar[t.Season].order, seasonCalendar[other.Season].order)
+}
+
+// HasStarted reports whether classes have begun by the given date.
+func (t Term) HasStarted(date time.Time) bool {
+	return t.StartDate <= date.Format(dateLayout)
+}
+
+// HasEnded reports whether classes are over by the given date.
+func (t Term) HasEnded(date time.Time) bool {
+	return t.EndDate < date.Format(dateLayout)
+}
+
+// CompareTermCodes orders term codes chronologically. Codes that do not parse
+// sort after every valid term, in lexical order.
+func CompareTermCodes(a, b string) int {
+	termA, errA := ParseTerm(a)
+	termB, errB := ParseTerm(b)
+	switch {
+	case errA == nil && errB == nil:
+		if order := termA.Compare(termB); order != 0 {
+			return order
+		}
+		return strings.Compare(a, b)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// SortTermCodes sorts term codes chronologically in place.
+func SortTermCodes(codes []string) {
+	slices.SortStableFunc(codes, CompareTermCodes)
+}